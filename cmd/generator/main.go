@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/generator"
+)
+
+func main() {
+	var (
+		configPath string
+		specPath   string
+		root       string
+		force      bool
+	)
+	flag.StringVar(&configPath, "config", "config/generator/resources.yaml", "Path to the resource mapping config.")
+	flag.StringVar(&specPath, "spec", "", "Path to the Atlas Admin API OpenAPI spec. Defaults to the spec shipped with the configured SDK module.")
+	flag.StringVar(&root, "root", ".", "Repository root.")
+	flag.BoolVar(&force, "force", false, "Overwrite existing reconcilers.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [kind...]\n\nGenerates the given kinds, or all configured kinds if none are given.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(configPath, specPath, root, force, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, specPath, root string, force bool, kinds []string) error {
+	cfg, err := generator.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	specs := map[string]*generator.Spec{}
+	for i := range cfg.Resources {
+		r := &cfg.Resources[i]
+		if len(kinds) > 0 && !contains(kinds, r.Kind) {
+			continue
+		}
+
+		path := specPath
+		if path == "" {
+			if path, err = sdkSpecPath(r.SDK); err != nil {
+				return err
+			}
+		}
+
+		spec, ok := specs[path]
+		if !ok {
+			if spec, err = generator.LoadSpec(path); err != nil {
+				return err
+			}
+			specs[path] = spec
+		}
+
+		g := generator.New(spec, root)
+		g.Force = force
+		if err := g.Generate(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sdkSpecPath locates the OpenAPI spec bundled with the given Atlas Go SDK module version.
+func sdkSpecPath(sdk string) (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "go.mongodb.org/atlas-sdk/"+sdk).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate SDK module %s: %w", sdk, err)
+	}
+	return filepath.Join(strings.TrimSpace(string(out)), "openapi", "atlas-api-transformed.yaml"), nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if strings.EqualFold(e, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/importer"
+)
+
+func main() {
+	var (
+		groupID   string
+		namespace string
+		dryRun    bool
+	)
+	flag.StringVar(&groupID, "group-id", "", "ID of the Atlas project to import.")
+	flag.StringVar(&namespace, "namespace", "default", "Namespace to create the objects in.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the objects to create instead of creating them.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n\nImports the clusters, flex clusters and the IP access list of an Atlas project which are not managed by any object yet.\nThe Atlas credentials are read from MCLI_PUBLIC_API_KEY, MCLI_PRIVATE_API_KEY and MCLI_OPS_MANAGER_URL.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), groupID, namespace, dryRun); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, groupID, namespace string, dryRun bool) error {
+	if groupID == "" {
+		return errors.New("-group-id is required")
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	cs, err := atlas.NewClientSet()
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		result, err := importer.Discover(ctx, c, cs, groupID)
+		if err != nil {
+			return err
+		}
+		for _, u := range importer.Plan(result, namespace) {
+			data, err := yaml.Marshal(u.Object)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("---\n%s", data)
+		}
+		return nil
+	}
+
+	result, err := importer.Import(ctx, c, cs, groupID, namespace)
+	if result != nil {
+		for _, r := range result.Resources {
+			fmt.Printf("%s %s: %s/%s\n", r.Kind, r.ExternalName, r.Namespace, r.Name)
+		}
+	}
+	return err
+}
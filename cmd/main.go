@@ -38,13 +38,38 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	alertconfiguration20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/alertconfiguration/v20231115"
+	auditing20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/auditing/v20231115"
+	backuprestorejob20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/backuprestorejob/v20231115"
+	backupschedule20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/backupschedule/v20231115"
+	backupsnapshot20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/backupsnapshot/v20231115"
+	cloudprovideraccessrole20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/cloudprovideraccessrole/v20231115"
 	cluster20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/cluster/v20231115"
+	customdatabaserole20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/customdatabaserole/v20231115"
+	databaseuser20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/databaseuser/v20231115"
+	datafederation20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/datafederation/v20231115"
+	encryptionatrest20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/encryptionatrest/v20231115"
 	flexv20241113 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/flex/v20241113"
 	group20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/group/v20231115"
+	importjob20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/importjob/v20231115"
+	networkcontainer20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/networkcontainer/v20231115"
+	networkpeeringconnection20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/networkpeeringconnection/v20231115"
 	networkpermissionentry20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/networkpermissionentry/v20231115"
+	onlinearchive20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/onlinearchive/v20231115"
+	organization20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/organization/v20231115"
+	privateendpointinterface20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/privateendpointinterface/v20231115"
+	privateendpointservice20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/privateendpointservice/v20231115"
+	programmaticapikey20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/programmaticapikey/v20231115"
+	searchindex20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/searchindex/v20231115"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
+	streamconnection20241113 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/streamconnection/v20241113"
+	streaminstance20241113 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/streaminstance/v20241113"
+	team20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/team/v20231115"
+	teamassignment20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/teamassignment/v20231115"
+	thirdpartyintegration20231115 "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/thirdpartyintegration/v20231115"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/unstructured"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ratelimiter"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
 )
 
 var (
@@ -101,68 +126,63 @@ func main() {
 
 	rl := ratelimiter.NewRateLimiter[reconcile.Request]()
 
-	for _, reconciler := range []managerInitializer{
-		&unstructured.Reconciler{
+	newController := func(kind string, r state.StateReconciler, watches ...unstructured.Watch) managerInitializer {
+		return &unstructured.Reconciler{
 			RateLimiter: rl,
+			Watches:     watches,
 			GVK: schema.GroupVersionKind{
 				Group:   "atlas.generated.mongodb.com",
 				Version: "v1",
-				Kind:    "Group",
+				Kind:    kind,
 			},
 			Reconciler: &state.Reconciler{
 				RateLimiter: rl,
 				Client:      mgr.GetClient(),
-				Reconciler: &group20231115.Reconciler{
-					Client: mgr.GetClient(),
-				},
-			},
-		},
-
-		&unstructured.Reconciler{
-			RateLimiter: rl,
-			GVK: schema.GroupVersionKind{
-				Group:   "atlas.generated.mongodb.com",
-				Version: "v1",
-				Kind:    "FlexCluster",
-			},
-			Reconciler: &state.Reconciler{
-				RateLimiter: rl,
-				Client:      mgr.GetClient(),
-				Reconciler: &flexv20241113.Reconciler{
-					Client: mgr.GetClient(),
-				},
-			},
-		},
-
-		&unstructured.Reconciler{
-			RateLimiter: rl,
-			GVK: schema.GroupVersionKind{
-				Group:   "atlas.generated.mongodb.com",
-				Version: "v1",
-				Kind:    "Cluster",
+				Reconciler:  r,
 			},
-			Reconciler: &state.Reconciler{
-				RateLimiter: rl,
-				Client:      mgr.GetClient(),
-				Reconciler: &cluster20231115.Reconciler{
-					Client: mgr.GetClient(),
-				},
-			},
-		},
+		}
+	}
 
-		&unstructured.Reconciler{
-			RateLimiter: rl,
-			GVK: schema.GroupVersionKind{
-				Group:   "atlas.generated.mongodb.com",
-				Version: "v1",
-				Kind:    "NetworkPermissionEntry",
-			},
-			Reconciler: &state.Reconciler{
-				RateLimiter: rl,
-				Client:      mgr.GetClient(),
-				Reconciler:  &networkpermissionentry20231115.Reconciler{},
-			},
-		},
+	for _, reconciler := range []managerInitializer{
+		newController("Group", group20231115.NewReconciler(mgr.GetClient())),
+		newController("FlexCluster", flexv20241113.NewReconciler(mgr.GetClient())),
+		newController("Cluster", cluster20231115.NewReconciler(mgr.GetClient())),
+		newController("NetworkPermissionEntry", networkpermissionentry20231115.NewReconciler(mgr.GetClient())),
+		newController("DatabaseUser", databaseuser20231115.NewReconciler(mgr.GetClient()),
+			secret.Watch("spec", "v20231115", "passwordSecretRef", "name"),
+		),
+		newController("CustomDatabaseRole", customdatabaserole20231115.NewReconciler(mgr.GetClient())),
+		newController("Team", team20231115.NewReconciler(mgr.GetClient())),
+		newController("TeamAssignment", teamassignment20231115.NewReconciler(mgr.GetClient())),
+		newController("BackupSchedule", backupschedule20231115.NewReconciler(mgr.GetClient())),
+		newController("BackupSnapshot", backupsnapshot20231115.NewReconciler(mgr.GetClient())),
+		newController("BackupRestoreJob", backuprestorejob20231115.NewReconciler(mgr.GetClient())),
+		newController("PrivateEndpointService", privateendpointservice20231115.NewReconciler(mgr.GetClient())),
+		newController("PrivateEndpointInterface", privateendpointinterface20231115.NewReconciler(mgr.GetClient())),
+		newController("NetworkContainer", networkcontainer20231115.NewReconciler(mgr.GetClient())),
+		newController("NetworkPeeringConnection", networkpeeringconnection20231115.NewReconciler(mgr.GetClient())),
+		newController("AlertConfiguration", alertconfiguration20231115.NewReconciler(mgr.GetClient()),
+			secret.Watch("spec", "v20231115", "notificationSecretRef", "name"),
+		),
+		newController("SearchIndex", searchindex20231115.NewReconciler(mgr.GetClient())),
+		newController("EncryptionAtRest", encryptionatrest20231115.NewReconciler(mgr.GetClient()),
+			secret.Watch("spec", "v20231115", "credentialsSecretRef", "name"),
+		),
+		newController("Auditing", auditing20231115.NewReconciler(mgr.GetClient())),
+		newController("ThirdPartyIntegration", thirdpartyintegration20231115.NewReconciler(mgr.GetClient()),
+			secret.Watch("spec", "v20231115", "credentialsSecretRef", "name"),
+		),
+		newController("OnlineArchive", onlinearchive20231115.NewReconciler(mgr.GetClient())),
+		newController("DataFederation", datafederation20231115.NewReconciler(mgr.GetClient())),
+		newController("StreamInstance", streaminstance20241113.NewReconciler(mgr.GetClient())),
+		newController("StreamConnection", streamconnection20241113.NewReconciler(mgr.GetClient()),
+			secret.Watch("spec", "v20241113", "authenticationSecretRef", "name"),
+		),
+		newController("CloudProviderAccessRole", cloudprovideraccessrole20231115.NewReconciler(mgr.GetClient())),
+		newController("Organization", organization20231115.NewReconciler(mgr.GetClient())),
+		newController("ProgrammaticAPIKey", programmaticapikey20231115.NewReconciler(mgr.GetClient())),
+		newController("ImportJob", importjob20231115.NewReconciler(mgr.GetClient())),
+		//+generator:scaffold:controller
 	} {
 		if err := reconciler.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", fmt.Sprintf("%T", reconciler))
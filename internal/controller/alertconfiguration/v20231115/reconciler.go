@@ -0,0 +1,215 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const version = "v20231115"
+
+// notificationSecretFields are the sensitive notification fields per notification type which are read from
+// the Secret referenced in spec.v20231115.notificationSecretRef. Atlas returns them redacted.
+var notificationSecretFields = map[string][]string{
+	"DATADOG":         {"datadogApiKey"},
+	"MICROSOFT_TEAMS": {"microsoftTeamsWebhookUrl"},
+	"OPS_GENIE":       {"opsGenieApiKey"},
+	"PAGER_DUTY":      {"serviceKey"},
+	"SLACK":           {"apiToken"},
+	"VICTOR_OPS":      {"victorOpsApiKey", "victorOpsRoutingKey"},
+	"WEBHOOK":         {"webhookUrl", "webhookSecret"},
+}
+
+// Reconciler manages a project alert configuration.
+//
+// Sensitive notification fields are read from the Secret referenced in spec.v20231115.notificationSecretRef.
+// The key "<index>.<field>", i.e. "0.serviceKey", applies to the notification at the given index only,
+// the key "<field>" to all notifications of a type using the field.
+// Updates are only sent if the entry differs from the configuration in Atlas or the Secret values changed.
+type Reconciler = generic.Reconciler[atlas20231115.GetAlertConfigurationApiParams, atlas20231115.GroupAlertsConfig]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "alert configuration",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetAlertConfigurationApiParams, error) {
+			var (
+				id  atlas20231115.GetAlertConfigurationApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.AlertConfigId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetAlertConfigurationApiParams {
+			status := generic.Status[atlas20231115.GroupAlertsConfig](u, version)
+			return atlas20231115.GetAlertConfigurationApiParams{
+				GroupId:       status.GetGroupId(),
+				AlertConfigId: status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.GroupAlertsConfig, error) {
+			params := generic.Params[atlas20231115.CreateAlertConfigurationApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.GroupAlertsConfig = generic.Entry[atlas20231115.GroupAlertsConfig](u, version)
+
+			hash, err := setNotificationSecrets(ctx, c, u, params.GroupAlertsConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			response, _, err := cs.SdkClient20231115008.AlertConfigurationsApi.CreateAlertConfigurationWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAlertConfigurationApiParams) (*atlas20231115.GroupAlertsConfig, error) {
+			response, _, err := cs.SdkClient20231115008.AlertConfigurationsApi.GetAlertConfigurationWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAlertConfigurationApiParams, u *unstructured.Unstructured) (*atlas20231115.GroupAlertsConfig, error) {
+			params := &atlas20231115.UpdateAlertConfigurationApiParams{
+				GroupId:           id.GroupId,
+				AlertConfigId:     id.AlertConfigId,
+				GroupAlertsConfig: generic.Entry[atlas20231115.GroupAlertsConfig](u, version),
+			}
+
+			current := generic.Status[atlas20231115.GroupAlertsConfig](u, version)
+			upToDate := matches(params.GroupAlertsConfig, current)
+
+			hash, err := setNotificationSecrets(ctx, c, u, params.GroupAlertsConfig)
+			if err != nil {
+				return nil, err
+			}
+			if upToDate && hash == secret.AppliedHash(u) {
+				return current, nil
+			}
+
+			response, _, err := cs.SdkClient20231115008.AlertConfigurationsApi.UpdateAlertConfigurationWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAlertConfigurationApiParams) error {
+			params := &atlas20231115.DeleteAlertConfigurationApiParams{
+				GroupId:       id.GroupId,
+				AlertConfigId: id.AlertConfigId,
+			}
+			_, err := cs.SdkClient20231115008.AlertConfigurationsApi.DeleteAlertConfigurationWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "ALERT_CONFIG_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// configurations changed in Atlas and rotated Secret values are applied although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			entry := generic.Entry[atlas20231115.GroupAlertsConfig](u, version)
+			if !matches(entry, generic.Status[atlas20231115.GroupAlertsConfig](u, version)) {
+				return true, nil
+			}
+
+			hash, err := setNotificationSecrets(ctx, c, u, entry)
+			if err != nil {
+				return false, err
+			}
+			return hash != secret.AppliedHash(u), nil
+		},
+
+		RequeueAfter: generic.Resync,
+
+		ImportEntry: func(response *atlas20231115.GroupAlertsConfig) any {
+			entry := *json.Convert[map[string]any](response)
+			for _, field := range []string{"created", "updated", "groupId", "id", "links"} {
+				delete(entry, field)
+			}
+			deleteNotificationSecrets(entry)
+			return entry
+		},
+	})
+}
+
+// setNotificationSecrets sets the sensitive notification fields of the given configuration from the Secret
+// referenced in spec.v20231115.notificationSecretRef and returns the hash of the applied values.
+func setNotificationSecrets(ctx context.Context, c client.Client, u *unstructured.Unstructured, config *atlas20231115.GroupAlertsConfig) (string, error) {
+	ref := secret.GetRef(u, "spec", version, "notificationSecretRef")
+	if ref == nil {
+		return "", nil
+	}
+
+	data, err := secret.Data(ctx, c, u.GetNamespace(), ref)
+	if err != nil {
+		return "", err
+	}
+
+	fields := *json.Convert[map[string]any](config)
+	notifications, _ := fields["notifications"].([]any)
+
+	var values []string
+	for i, n := range notifications {
+		notification, _ := n.(map[string]any)
+		typeName, _ := notification["typeName"].(string)
+		for _, field := range notificationSecretFields[typeName] {
+			v, ok := data[fmt.Sprintf("%d.%s", i, field)]
+			if !ok {
+				v, ok = data[field]
+			}
+			if !ok {
+				continue
+			}
+			notification[field] = string(v)
+			values = append(values, fmt.Sprintf("%d.%s", i, field), string(v))
+		}
+	}
+
+	*config = *json.Convert[atlas20231115.GroupAlertsConfig](fields)
+	return secret.Hash(values...), nil
+}
+
+// matches reports whether all fields set in the desired configuration have the same value in Atlas.
+// Fields defaulted by Atlas and redacted notification secrets are not considered.
+func matches(desired, current *atlas20231115.GroupAlertsConfig) bool {
+	fields := *json.Convert[map[string]any](desired)
+	deleteNotificationSecrets(fields)
+	return len(json.ChangedFields(fields, current)) == 0
+}
+
+// deleteNotificationSecrets removes the sensitive fields from the notifications of the given configuration.
+func deleteNotificationSecrets(config map[string]any) {
+	notifications, _ := config["notifications"].([]any)
+	for _, n := range notifications {
+		notification, _ := n.(map[string]any)
+		typeName, _ := notification["typeName"].(string)
+		for _, field := range notificationSecretFields[typeName] {
+			delete(notification, field)
+		}
+	}
+}
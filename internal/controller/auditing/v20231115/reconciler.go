@@ -0,0 +1,128 @@
+package v20231115
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages the database auditing configuration of a project.
+//
+// spec.v20231115.entry.auditFilter has to be a JSON object, it is validated before being sent to Atlas.
+// The configuration is compared against Atlas on every reconciliation and restored if it was changed there.
+// Atlas reports whether the filter was set through the API or the UI in status.v20231115.configurationType.
+// Deleting the resource disables auditing.
+type Reconciler = generic.Reconciler[atlas20231115.GetAuditingConfigurationApiParams, atlas20231115.AuditLog]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "auditing",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetAuditingConfigurationApiParams, error) {
+			groupID, err := generic.Annotation(annotations, "mongodb.com/external-group-id")
+			return atlas20231115.GetAuditingConfigurationApiParams{GroupId: groupID}, err
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetAuditingConfigurationApiParams {
+			return atlas20231115.GetAuditingConfigurationApiParams{GroupId: ref.RecordedGroupID(u)}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.AuditLog, error) {
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			return update(ctx, cs, groupID, generic.Entry[atlas20231115.AuditLog](u, version))
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAuditingConfigurationApiParams) (*atlas20231115.AuditLog, error) {
+			response, _, err := cs.SdkClient20231115008.AuditingApi.GetAuditingConfigurationWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAuditingConfigurationApiParams, u *unstructured.Unstructured) (*atlas20231115.AuditLog, error) {
+			return update(ctx, cs, id.GroupId, generic.Entry[atlas20231115.AuditLog](u, version))
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAuditingConfigurationApiParams) error {
+			_, err := update(ctx, cs, id.GroupId, &atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(false)})
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// configurations changed in Atlas are restored although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			return drifted(generic.Entry[atlas20231115.AuditLog](u, version), generic.Status[atlas20231115.AuditLog](u, version)), nil
+		},
+
+		RequeueAfter: generic.Resync,
+
+		ImportEntry: func(response *atlas20231115.AuditLog) any {
+			return atlas20231115.AuditLog{
+				AuditAuthorizationSuccess: response.AuditAuthorizationSuccess,
+				AuditFilter:               response.AuditFilter,
+				Enabled:                   response.Enabled,
+			}
+		},
+	})
+}
+
+func update(ctx context.Context, cs *atlas.ClientSet, groupID string, config *atlas20231115.AuditLog) (*atlas20231115.AuditLog, error) {
+	if config.AuditFilter != nil {
+		if _, err := parseFilter(config.GetAuditFilter()); err != nil {
+			return nil, fmt.Errorf("auditFilter is not a JSON object: %w", err)
+		}
+	}
+
+	params := &atlas20231115.UpdateAuditingConfigurationApiParams{
+		GroupId:  groupID,
+		AuditLog: config,
+	}
+	response, _, err := cs.SdkClient20231115008.AuditingApi.UpdateAuditingConfigurationWithParams(ctx, params).Execute()
+	return response, err
+}
+
+// drifted reports whether a field set in the desired configuration differs from Atlas.
+// Audit filters are compared as JSON objects, so formatting differences are not considered.
+func drifted(desired, current *atlas20231115.AuditLog) bool {
+	if len(json.ChangedFields(desired, current, "auditFilter")) > 0 {
+		return true
+	}
+	if desired.AuditFilter == nil {
+		return false
+	}
+	desiredFilter, errDesired := parseFilter(desired.GetAuditFilter())
+	currentFilter, errCurrent := parseFilter(current.GetAuditFilter())
+	if errDesired != nil || errCurrent != nil {
+		return desired.GetAuditFilter() != current.GetAuditFilter()
+	}
+	return !reflect.DeepEqual(desiredFilter, currentFilter)
+}
+
+// parseFilter parses an audit filter, which has to be a JSON object.
+func parseFilter(filter string) (map[string]any, error) {
+	var result map[string]any
+	if err := json.Unmarshal([]byte(filter), &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("filter is null")
+	}
+	return result, nil
+}
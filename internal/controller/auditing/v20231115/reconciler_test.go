@@ -0,0 +1,72 @@
+package v20231115
+
+import (
+	"testing"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+)
+
+func TestParseFilter(t *testing.T) {
+	for _, tc := range []struct {
+		filter  string
+		wantErr bool
+	}{
+		{filter: `{}`},
+		{filter: `{"atype": "authenticate"}`},
+		{filter: `1`, wantErr: true},
+		{filter: `"x"`, wantErr: true},
+		{filter: `null`, wantErr: true},
+		{filter: `[{"atype": "authenticate"}]`, wantErr: true},
+		{filter: `{"atype":`, wantErr: true},
+	} {
+		t.Run(tc.filter, func(t *testing.T) {
+			if _, err := parseFilter(tc.filter); (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDrifted(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		desired atlas20231115.AuditLog
+		current atlas20231115.AuditLog
+		want    bool
+	}{
+		{
+			name:    "equal",
+			desired: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(true), AuditFilter: atlas20231115.PtrString(`{"atype": "authenticate"}`)},
+			current: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(true), AuditFilter: atlas20231115.PtrString(`{"atype":"authenticate"}`), ConfigurationType: atlas20231115.PtrString("FILTER_JSON")},
+		},
+		{
+			name:    "disabled in Atlas",
+			desired: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(true)},
+			current: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(false)},
+			want:    true,
+		},
+		{
+			name:    "filter changed in Atlas",
+			desired: atlas20231115.AuditLog{AuditFilter: atlas20231115.PtrString(`{"atype": "authenticate"}`)},
+			current: atlas20231115.AuditLog{AuditFilter: atlas20231115.PtrString(`{"atype": "authCheck"}`)},
+			want:    true,
+		},
+		{
+			name:    "filter field added in Atlas",
+			desired: atlas20231115.AuditLog{AuditFilter: atlas20231115.PtrString(`{"atype": "authenticate"}`)},
+			current: atlas20231115.AuditLog{AuditFilter: atlas20231115.PtrString(`{"atype": "authenticate", "users": []}`)},
+			want:    true,
+		},
+		{
+			name:    "unset fields are not compared",
+			desired: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(true)},
+			current: atlas20231115.AuditLog{Enabled: atlas20231115.PtrBool(true), AuditAuthorizationSuccess: atlas20231115.PtrBool(true), AuditFilter: atlas20231115.PtrString(`{}`)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := drifted(&tc.desired, &tc.current); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages a restore job of a cloud backup snapshot.
+//
+// The cluster owning the snapshot is referenced by spec.v20231115.clusterRef or spec.v20231115.parameters.clusterName,
+// the snapshot by spec.v20231115.snapshotRef or spec.v20231115.entry.snapshotId and the target cluster of automated
+// restores by spec.v20231115.targetClusterRef or spec.v20231115.entry.targetClusterName and targetGroupId.
+//
+// The job stays in the Creating state until Atlas finished it, failed and cancelled jobs are reported as errors.
+// Deleting a restore job only cancels it if it is still running, completed restores are left as they are.
+type Reconciler = generic.Reconciler[atlas20231115.GetBackupRestoreJobApiParams, atlas20231115.DiskBackupSnapshotRestoreJob]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "backup restore job",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetBackupRestoreJobApiParams, error) {
+			var (
+				id  atlas20231115.GetBackupRestoreJobApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ClusterName, err = generic.Annotation(annotations, "mongodb.com/external-cluster-name"); err != nil {
+				return id, err
+			}
+			if id.RestoreJobId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetBackupRestoreJobApiParams {
+			status := generic.Status[atlas20231115.DiskBackupSnapshotRestoreJob](u, version)
+			return atlas20231115.GetBackupRestoreJobApiParams{
+				GroupId:      ref.RecordedGroupID(u),
+				ClusterName:  ref.RecordedClusterName(u),
+				RestoreJobId: status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.DiskBackupSnapshotRestoreJob, error) {
+			groupID, clusterName, err := ref.Cluster(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			job := generic.Entry[atlas20231115.DiskBackupSnapshotRestoreJob](u, version)
+			if err := resolveSnapshot(ctx, c, u, job); err != nil {
+				return nil, err
+			}
+			if err := resolveTarget(ctx, c, u, job); err != nil {
+				return nil, err
+			}
+
+			params := &atlas20231115.CreateBackupRestoreJobApiParams{
+				GroupId:                      groupID,
+				ClusterName:                  clusterName,
+				DiskBackupSnapshotRestoreJob: job,
+			}
+			response, _, err := cs.SdkClient20231115008.CloudBackupsApi.CreateBackupRestoreJobWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupRestoreJobApiParams) (*atlas20231115.DiskBackupSnapshotRestoreJob, error) {
+			response, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetBackupRestoreJobWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupRestoreJobApiParams, u *unstructured.Unstructured) (*atlas20231115.DiskBackupSnapshotRestoreJob, error) {
+			// restore jobs are immutable in Atlas, a new BackupRestoreJob has to be created to restore again.
+			return generic.Status[atlas20231115.DiskBackupSnapshotRestoreJob](u, version), nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupRestoreJobApiParams) error {
+			job, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetBackupRestoreJobWithParams(ctx, &id).Execute()
+			if err != nil {
+				return err
+			}
+			if jobState(job) != "IN_PROGRESS" {
+				return nil
+			}
+
+			params := &atlas20231115.CancelBackupRestoreJobApiParams{
+				GroupId:      id.GroupId,
+				ClusterName:  id.ClusterName,
+				RestoreJobId: id.RestoreJobId,
+			}
+			_, _, err = cs.SdkClient20231115008.CloudBackupsApi.CancelBackupRestoreJobWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "RESTORE_JOB_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		StateName:    jobState,
+		BusyStates:   []string{"IN_PROGRESS"},
+		FailedStates: []string{"FAILED", "CANCELLED"},
+		SyncDelete:   true,
+	})
+}
+
+// jobState derives the lifecycle state of a restore job from its flags.
+func jobState(job *atlas20231115.DiskBackupSnapshotRestoreJob) string {
+	switch {
+	case job.GetCancelled():
+		return "CANCELLED"
+	case job.GetFailed():
+		return "FAILED"
+	case job.GetExpired():
+		return "EXPIRED"
+	case job.FinishedAt != nil:
+		return "COMPLETED"
+	}
+	return "IN_PROGRESS"
+}
+
+// resolveSnapshot sets the ID of the BackupSnapshot referenced in spec.<version>.snapshotRef on the job.
+func resolveSnapshot(ctx context.Context, c client.Reader, u *unstructured.Unstructured, job *atlas20231115.DiskBackupSnapshotRestoreJob) error {
+	snapshot, err := ref.Get(ctx, c, u, "BackupSnapshot", "spec", version, "snapshotRef")
+	if err != nil || snapshot == nil {
+		return err
+	}
+	if !ref.IsReady(snapshot) {
+		return fmt.Errorf("referenced BackupSnapshot %s is not completed yet", snapshot.GetName())
+	}
+
+	snapshotID, _, _ := unstructured.NestedString(snapshot.Object, "status", "v20231115", "id")
+	job.SnapshotId = &snapshotID
+	return nil
+}
+
+// resolveTarget sets the project ID and name of the Cluster referenced in spec.<version>.targetClusterRef on the job.
+func resolveTarget(ctx context.Context, c client.Reader, u *unstructured.Unstructured, job *atlas20231115.DiskBackupSnapshotRestoreJob) error {
+	target, err := ref.Get(ctx, c, u, "Cluster", "spec", version, "targetClusterRef")
+	if err != nil || target == nil {
+		return err
+	}
+	if !ref.IsReady(target) {
+		return fmt.Errorf("referenced target Cluster %s is not ready yet", target.GetName())
+	}
+
+	groupID, _, _ := unstructured.NestedString(target.Object, "status", "v20231115", "groupId")
+	clusterName, _, _ := unstructured.NestedString(target.Object, "status", "v20231115", "name")
+	job.TargetGroupId = &groupID
+	job.TargetClusterName = &clusterName
+	return nil
+}
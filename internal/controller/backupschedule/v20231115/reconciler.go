@@ -0,0 +1,208 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// defaultPolicyItems mirror the snapshot schedule Atlas applies to new clusters.
+var defaultPolicyItems = []atlas20231115.DiskBackupApiPolicyItem{
+	{FrequencyType: "hourly", FrequencyInterval: 6, RetentionUnit: "days", RetentionValue: 2},
+	{FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+	{FrequencyType: "weekly", FrequencyInterval: 6, RetentionUnit: "weeks", RetentionValue: 4},
+	{FrequencyType: "monthly", FrequencyInterval: 40, RetentionUnit: "months", RetentionValue: 12},
+}
+
+// writeOnlyFields are applied together with other changes but never reported back by Atlas.
+var writeOnlyFields = []string{"deleteCopiedBackups", "updateSnapshots"}
+
+// Reconciler manages the cloud backup schedule of a cluster.
+//
+// The cluster is referenced by spec.v20231115.clusterRef or spec.v20231115.parameters.clusterName.
+// A schedule always exists in Atlas for clusters with cloud backup enabled, so creating one patches the existing
+// schedule and deleting one restores the Atlas default policy. Only fields set in spec.v20231115.entry are managed
+// and only the ones differing from Atlas are sent.
+type Reconciler = generic.Reconciler[atlas20231115.GetBackupScheduleApiParams, atlas20231115.DiskBackupSnapshotSchedule]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "backup schedule",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetBackupScheduleApiParams, error) {
+			var (
+				id  atlas20231115.GetBackupScheduleApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ClusterName, err = generic.Annotation(annotations, "mongodb.com/external-cluster-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetBackupScheduleApiParams {
+			return atlas20231115.GetBackupScheduleApiParams{
+				GroupId:     ref.RecordedGroupID(u),
+				ClusterName: ref.RecordedClusterName(u),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.DiskBackupSnapshotSchedule, error) {
+			groupID, clusterName, err := ref.Cluster(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			id := atlas20231115.GetBackupScheduleApiParams{GroupId: groupID, ClusterName: clusterName}
+			current, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetBackupScheduleWithParams(ctx, &id).Execute()
+			if err != nil {
+				return nil, err
+			}
+			return update(ctx, cs, id, generic.Entry[atlas20231115.DiskBackupSnapshotSchedule](u, version), current)
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupScheduleApiParams) (*atlas20231115.DiskBackupSnapshotSchedule, error) {
+			response, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetBackupScheduleWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupScheduleApiParams, u *unstructured.Unstructured) (*atlas20231115.DiskBackupSnapshotSchedule, error) {
+			current := generic.Status[atlas20231115.DiskBackupSnapshotSchedule](u, version)
+			return update(ctx, cs, id, generic.Entry[atlas20231115.DiskBackupSnapshotSchedule](u, version), current)
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupScheduleApiParams) error {
+			current, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetBackupScheduleWithParams(ctx, &id).Execute()
+			if err != nil {
+				return err
+			}
+
+			policy := atlas20231115.AdvancedDiskBackupSnapshotSchedulePolicy{PolicyItems: &defaultPolicyItems}
+			if policies := current.GetPolicies(); len(policies) > 0 {
+				policy.Id = policies[0].Id
+			}
+			params := &atlas20231115.UpdateBackupScheduleApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				DiskBackupSnapshotSchedule: &atlas20231115.DiskBackupSnapshotSchedule{
+					Policies:     &[]atlas20231115.AdvancedDiskBackupSnapshotSchedulePolicy{policy},
+					CopySettings: &[]atlas20231115.DiskBackupCopySetting{},
+				},
+			}
+			_, _, err = cs.SdkClient20231115008.CloudBackupsApi.UpdateBackupScheduleWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// schedules changed in Atlas are restored although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			desired := generic.Entry[atlas20231115.DiskBackupSnapshotSchedule](u, version)
+			current := generic.Status[atlas20231115.DiskBackupSnapshotSchedule](u, version)
+			return len(changes(desired, current)) > 0, nil
+		},
+
+		RequeueAfter: generic.Resync,
+
+		ImportEntry: func(response *atlas20231115.DiskBackupSnapshotSchedule) any {
+			entry := *response
+			entry.ClusterId = nil
+			entry.ClusterName = nil
+			entry.Links = nil
+			entry.NextSnapshot = nil
+			return entry
+		},
+	})
+}
+
+// update patches the fields of the desired schedule which differ from the current one.
+func update(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetBackupScheduleApiParams, desired, current *atlas20231115.DiskBackupSnapshotSchedule) (*atlas20231115.DiskBackupSnapshotSchedule, error) {
+	patch := changes(desired, current)
+	if len(patch) == 0 {
+		return current, nil
+	}
+
+	desiredFields := json.Convert[map[string]any](desired)
+	for _, field := range writeOnlyFields {
+		if v, ok := (*desiredFields)[field]; ok {
+			patch[field] = v
+		}
+	}
+
+	schedule := json.Convert[atlas20231115.DiskBackupSnapshotSchedule](patch)
+	if schedule.Policies != nil {
+		// Atlas identifies the policies to update by their ID.
+		currentPolicies := current.GetPolicies()
+		for i := range *schedule.Policies {
+			if policy := &(*schedule.Policies)[i]; policy.Id == nil && i < len(currentPolicies) {
+				policy.Id = currentPolicies[i].Id
+			}
+		}
+	}
+
+	params := &atlas20231115.UpdateBackupScheduleApiParams{
+		GroupId:                    id.GroupId,
+		ClusterName:                id.ClusterName,
+		DiskBackupSnapshotSchedule: schedule,
+	}
+	response, _, err := cs.SdkClient20231115008.CloudBackupsApi.UpdateBackupScheduleWithParams(ctx, params).Execute()
+	return response, err
+}
+
+// changes returns the top-level fields set in the desired schedule whose values differ from the current one.
+// Atlas generated IDs and the order of policy items are not considered.
+func changes(desired, current *atlas20231115.DiskBackupSnapshotSchedule) map[string]any {
+	desiredFields := *json.Convert[map[string]any](desired)
+	currentFields := *json.Convert[map[string]any](current)
+
+	patch := map[string]any{}
+	for _, field := range json.ChangedFields(normalize(desiredFields), normalize(currentFields), writeOnlyFields...) {
+		patch[field] = desiredFields[field]
+	}
+	return patch
+}
+
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, value := range v {
+			if key == "id" {
+				continue
+			}
+			result[key] = normalize(value)
+		}
+		if items, ok := result["policyItems"].([]any); ok {
+			slices.SortFunc(items, func(a, b any) int {
+				return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+			})
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, value := range v {
+			result = append(result, normalize(value))
+		}
+		return result
+	default:
+		return v
+	}
+}
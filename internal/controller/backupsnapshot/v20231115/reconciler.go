@@ -0,0 +1,142 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages an on-demand snapshot of a cluster.
+//
+// The cluster is referenced by spec.v20231115.clusterRef or spec.v20231115.parameters.clusterName.
+// The snapshot stays in the Creating state until Atlas completed it, failed snapshots are reported as errors.
+// Only the retention of a taken snapshot can be changed. Snapshots are tracked by the replica set snapshot
+// endpoints, so sharded clusters are not supported and rejected before a snapshot is taken.
+type Reconciler = generic.Reconciler[atlas20231115.GetReplicaSetBackupApiParams, atlas20231115.DiskBackupReplicaSet]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "backup snapshot",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetReplicaSetBackupApiParams, error) {
+			var (
+				id  atlas20231115.GetReplicaSetBackupApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ClusterName, err = generic.Annotation(annotations, "mongodb.com/external-cluster-name"); err != nil {
+				return id, err
+			}
+			if id.SnapshotId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetReplicaSetBackupApiParams {
+			status := generic.Status[atlas20231115.DiskBackupReplicaSet](u, version)
+			return atlas20231115.GetReplicaSetBackupApiParams{
+				GroupId:     ref.RecordedGroupID(u),
+				ClusterName: ref.RecordedClusterName(u),
+				SnapshotId:  status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.DiskBackupReplicaSet, error) {
+			groupID, clusterName, err := ref.Cluster(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			cluster, _, err := cs.SdkClient20231115008.ClustersApi.GetCluster(ctx, groupID, clusterName).Execute()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster %s: %w", clusterName, err)
+			}
+			if cluster.GetClusterType() != "REPLICASET" {
+				return nil, fmt.Errorf("snapshots of %s cluster %s are not supported, only replica sets are", cluster.GetClusterType(), clusterName)
+			}
+
+			params := &atlas20231115.TakeSnapshotApiParams{
+				GroupId:                           groupID,
+				ClusterName:                       clusterName,
+				DiskBackupOnDemandSnapshotRequest: generic.Entry[atlas20231115.DiskBackupOnDemandSnapshotRequest](u, version),
+			}
+			snapshot, _, err := cs.SdkClient20231115008.CloudBackupsApi.TakeSnapshotWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+
+			// the snapshot is tracked by the replica set snapshot endpoint all further operations use.
+			return &atlas20231115.DiskBackupReplicaSet{
+				CreatedAt:     snapshot.CreatedAt,
+				Description:   snapshot.Description,
+				ExpiresAt:     snapshot.ExpiresAt,
+				FrequencyType: snapshot.FrequencyType,
+				Id:            snapshot.Id,
+				Links:         snapshot.Links,
+				SnapshotType:  snapshot.SnapshotType,
+				Status:        snapshot.Status,
+				Type:          snapshot.Type,
+			}, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetReplicaSetBackupApiParams) (*atlas20231115.DiskBackupReplicaSet, error) {
+			response, _, err := cs.SdkClient20231115008.CloudBackupsApi.GetReplicaSetBackupWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetReplicaSetBackupApiParams, u *unstructured.Unstructured) (*atlas20231115.DiskBackupReplicaSet, error) {
+			entry := generic.Entry[atlas20231115.DiskBackupOnDemandSnapshotRequest](u, version)
+			if entry.RetentionInDays == nil {
+				return generic.Status[atlas20231115.DiskBackupReplicaSet](u, version), nil
+			}
+
+			params := &atlas20231115.UpdateSnapshotRetentionApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				SnapshotId:  id.SnapshotId,
+				BackupSnapshotRetention: &atlas20231115.BackupSnapshotRetention{
+					RetentionUnit:  "days",
+					RetentionValue: entry.GetRetentionInDays(),
+				},
+			}
+			response, _, err := cs.SdkClient20231115008.CloudBackupsApi.UpdateSnapshotRetentionWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetReplicaSetBackupApiParams) error {
+			params := &atlas20231115.DeleteReplicaSetBackupApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				SnapshotId:  id.SnapshotId,
+			}
+			_, _, err := cs.SdkClient20231115008.CloudBackupsApi.DeleteReplicaSetBackupWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "SNAPSHOT_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.DiskBackupReplicaSet) any {
+			return atlas20231115.DiskBackupOnDemandSnapshotRequest{Description: response.Description}
+		},
+
+		StateName:    (*atlas20231115.DiskBackupReplicaSet).GetStatus,
+		BusyStates:   []string{"queued", "inProgress"},
+		FailedStates: []string{"failed"},
+	})
+}
@@ -0,0 +1,140 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages a cloud provider access role of a project.
+//
+// AWS roles are set up in two phases. Atlas first creates the role and reports the AWS account and external ID
+// it assumes the IAM role with in status.v20231115.atlasAWSAccountArn and status.v20231115.atlasAssumedRoleExternalId.
+// The resource stays pending until the ARN of an IAM role trusting them is set in spec.v20231115.entry.iamAssumedRoleArn,
+// the role is then authorized. Azure service principals are authorized on creation.
+type Reconciler = generic.Reconciler[atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams, atlas20231115.CloudProviderAccessRole]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "cloud provider access role",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams, error) {
+			var (
+				id  atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.RoleId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams {
+			status := generic.Status[atlas20231115.CloudProviderAccessRole](u, version)
+			return atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams{
+				GroupId:       ref.RecordedGroupID(u),
+				CloudProvider: status.GetProviderName(),
+				RoleId:        roleID(status),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.CloudProviderAccessRole, error) {
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			role := generic.Entry[atlas20231115.CloudProviderAccessRole](u, version)
+			if role.ProviderName == "AWS" {
+				// the IAM role can only trust Atlas once the external ID is known, it is authorized on update.
+				role = &atlas20231115.CloudProviderAccessRole{ProviderName: role.ProviderName}
+			}
+			params := &atlas20231115.CreateCloudProviderAccessRoleApiParams{
+				GroupId:                 groupID,
+				CloudProviderAccessRole: role,
+			}
+			response, _, err := cs.SdkClient20231115008.CloudProviderAccessApi.CreateCloudProviderAccessRoleWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams) (*atlas20231115.CloudProviderAccessRole, error) {
+			params := &atlas20231115.GetCloudProviderAccessRoleApiParams{
+				GroupId: id.GroupId,
+				RoleId:  id.RoleId,
+			}
+			response, _, err := cs.SdkClient20231115008.CloudProviderAccessApi.GetCloudProviderAccessRoleWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams, u *unstructured.Unstructured) (*atlas20231115.CloudProviderAccessRole, error) {
+			current := generic.Status[atlas20231115.CloudProviderAccessRole](u, version)
+			entry := generic.Entry[atlas20231115.CloudProviderAccessRole](u, version)
+			if entry.ProviderName != current.ProviderName {
+				return nil, fmt.Errorf("providerName can not be changed from %s to %s", current.ProviderName, entry.ProviderName)
+			}
+			// only the IAM role of AWS roles can be changed.
+			if current.ProviderName != "AWS" || entry.GetIamAssumedRoleArn() == "" || entry.GetIamAssumedRoleArn() == current.GetIamAssumedRoleArn() {
+				return current, nil
+			}
+
+			params := &atlas20231115.AuthorizeCloudProviderAccessRoleApiParams{
+				GroupId: id.GroupId,
+				RoleId:  id.RoleId,
+				CloudProviderAccessRole: &atlas20231115.CloudProviderAccessRole{
+					ProviderName:      current.ProviderName,
+					IamAssumedRoleArn: entry.IamAssumedRoleArn,
+				},
+			}
+			response, _, err := cs.SdkClient20231115008.CloudProviderAccessApi.AuthorizeCloudProviderAccessRoleWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.DeauthorizeCloudProviderAccessRoleApiParams) error {
+			_, err := cs.SdkClient20231115008.CloudProviderAccessApi.DeauthorizeCloudProviderAccessRoleWithParams(ctx, &id).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "CLOUD_PROVIDER_ACCESS_ROLE_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		Pending: func(u *unstructured.Unstructured, response *atlas20231115.CloudProviderAccessRole) string {
+			if response.ProviderName != "AWS" || response.AuthorizedDate != nil {
+				return ""
+			}
+			return fmt.Sprintf("Waiting for authorization, create an IAM role trusting %s with external ID %s and set its ARN in spec.%s.entry.iamAssumedRoleArn",
+				response.GetAtlasAWSAccountArn(), response.GetAtlasAssumedRoleExternalId(), version)
+		},
+
+		ImportEntry: func(response *atlas20231115.CloudProviderAccessRole) any {
+			return atlas20231115.CloudProviderAccessRole{
+				ProviderName:       response.ProviderName,
+				IamAssumedRoleArn:  response.IamAssumedRoleArn,
+				AtlasAzureAppId:    response.AtlasAzureAppId,
+				ServicePrincipalId: response.ServicePrincipalId,
+				TenantId:           response.TenantId,
+			}
+		},
+	})
+}
+
+// roleID returns the ID of an AWS role or an Azure service principal.
+func roleID(role *atlas20231115.CloudProviderAccessRole) string {
+	if role.RoleId != nil {
+		return role.GetRoleId()
+	}
+	return role.GetId()
+}
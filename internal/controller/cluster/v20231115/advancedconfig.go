@@ -0,0 +1,47 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+)
+
+// applyAdvancedConfiguration sets the process arguments of spec.<version>.advancedConfiguration differing from Atlas.
+// Atlas rejects them while the cluster changes, so they are only applied to IDLE clusters and pending otherwise.
+// Fields changed outside of the operator are logged and set back.
+func applyAdvancedConfiguration(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetClusterApiParams, u *unstructured.Unstructured) error {
+	if stateName := generic.Status[atlas20231115.AdvancedClusterDescription](u, version).GetStateName(); stateName != "IDLE" {
+		return fmt.Errorf("%w, cluster is %s", generic.ErrSectionPending, stateName)
+	}
+
+	desired := generic.SectionValue[atlas20231115.ClusterDescriptionProcessArgs](u, version, "advancedConfiguration")
+	params := &atlas20231115.GetClusterAdvancedConfigurationApiParams{
+		GroupId:     id.GroupId,
+		ClusterName: id.ClusterName,
+	}
+	current, _, err := cs.SdkClient20231115008.ClustersApi.GetClusterAdvancedConfigurationWithParams(ctx, params).Execute()
+	if err != nil {
+		return err
+	}
+
+	fields := json.ChangedFields(desired, current)
+	if len(fields) == 0 {
+		return nil
+	}
+	log.FromContext(ctx).WithName("cluster-controller").Info("advanced configuration differs from Atlas", "fields", fields)
+
+	updateParams := &atlas20231115.UpdateClusterAdvancedConfigurationApiParams{
+		GroupId:                       id.GroupId,
+		ClusterName:                   id.ClusterName,
+		ClusterDescriptionProcessArgs: desired,
+	}
+	_, _, err = cs.SdkClient20231115008.ClustersApi.UpdateClusterAdvancedConfigurationWithParams(ctx, updateParams).Execute()
+	return err
+}
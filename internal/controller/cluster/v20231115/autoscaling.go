@@ -0,0 +1,125 @@
+package v20231115
+
+import (
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+// AutoScalingStatus is status.autoScaling.
+type AutoScalingStatus struct {
+	DiskSizeGB *float64            `json:"diskSizeGB,omitempty"`
+	Regions    []AutoScalingRegion `json:"regions,omitempty"`
+}
+
+// AutoScalingRegion holds the instance sizes Atlas chose for a region with compute auto-scaling.
+type AutoScalingRegion struct {
+	ZoneName              string `json:"zoneName,omitempty"`
+	ProviderName          string `json:"providerName,omitempty"`
+	RegionName            string `json:"regionName,omitempty"`
+	InstanceSize          string `json:"instanceSize,omitempty"`
+	AnalyticsInstanceSize string `json:"analyticsInstanceSize,omitempty"`
+}
+
+// withoutAutoScaledFields replaces the instance sizes and the disk size Atlas manages under the auto-scaling
+// policies of the entry with the values in Atlas, so updates don't revert scaling. Regions without a policy
+// in the entry keep the policy they have in Atlas.
+func withoutAutoScaledFields(entry, current *atlas20231115.AdvancedClusterDescription) {
+	diskAutoScaled := false
+	currentSpecs := current.GetReplicationSpecs()
+	for i, spec := range entry.GetReplicationSpecs() {
+		for _, config := range spec.GetRegionConfigs() {
+			var currentConfig *atlas20231115.CloudRegionConfig
+			if i < len(currentSpecs) {
+				currentConfig = findRegionConfig(currentSpecs[i].GetRegionConfigs(), config)
+			}
+			if currentConfig == nil {
+				continue
+			}
+
+			autoScaling := policy(config.AutoScaling, currentConfig.AutoScaling)
+			if computeAutoScaled(autoScaling) {
+				if config.ElectableSpecs != nil && currentConfig.ElectableSpecs != nil {
+					config.ElectableSpecs.InstanceSize = currentConfig.ElectableSpecs.InstanceSize
+				}
+				if config.ReadOnlySpecs != nil && currentConfig.ReadOnlySpecs != nil {
+					config.ReadOnlySpecs.InstanceSize = currentConfig.ReadOnlySpecs.InstanceSize
+				}
+			}
+			if computeAutoScaled(policy(config.AnalyticsAutoScaling, currentConfig.AnalyticsAutoScaling)) &&
+				config.AnalyticsSpecs != nil && currentConfig.AnalyticsSpecs != nil {
+				config.AnalyticsSpecs.InstanceSize = currentConfig.AnalyticsSpecs.InstanceSize
+			}
+			diskAutoScaled = diskAutoScaled || diskAutoScaledBy(autoScaling)
+		}
+	}
+
+	if diskAutoScaled && entry.DiskSizeGB != nil {
+		entry.DiskSizeGB = current.DiskSizeGB
+	}
+}
+
+// setAutoScalingStatus reports the values Atlas manages under active auto-scaling policies in status.autoScaling.
+func setAutoScalingStatus(u *unstructured.Unstructured, cluster *atlas20231115.AdvancedClusterDescription) {
+	status := AutoScalingStatus{}
+	diskAutoScaled := false
+	for _, spec := range cluster.GetReplicationSpecs() {
+		for _, config := range spec.GetRegionConfigs() {
+			computeEnabled := computeAutoScaled(config.AutoScaling)
+			analyticsEnabled := computeAutoScaled(config.AnalyticsAutoScaling)
+			diskAutoScaled = diskAutoScaled || diskAutoScaledBy(config.AutoScaling)
+			if !computeEnabled && !analyticsEnabled {
+				continue
+			}
+
+			region := AutoScalingRegion{
+				ZoneName:     spec.GetZoneName(),
+				ProviderName: config.GetProviderName(),
+				RegionName:   config.GetRegionName(),
+			}
+			if computeEnabled {
+				region.InstanceSize = config.ElectableSpecs.GetInstanceSize()
+			}
+			if analyticsEnabled {
+				region.AnalyticsInstanceSize = config.AnalyticsSpecs.GetInstanceSize()
+			}
+			status.Regions = append(status.Regions, region)
+		}
+	}
+	if diskAutoScaled {
+		status.DiskSizeGB = cluster.DiskSizeGB
+	}
+
+	if status.DiskSizeGB == nil && len(status.Regions) == 0 {
+		unstructured.RemoveNestedField(u.Object, "status", "autoScaling")
+		return
+	}
+	internalunstructured.SetNestedFieldObject(u.Object, status, "status", "autoScaling")
+}
+
+// findRegionConfig returns the region config of the same provider and region.
+func findRegionConfig(configs []atlas20231115.CloudRegionConfig, config atlas20231115.CloudRegionConfig) *atlas20231115.CloudRegionConfig {
+	for i := range configs {
+		if configs[i].GetProviderName() == config.GetProviderName() && configs[i].GetRegionName() == config.GetRegionName() {
+			return &configs[i]
+		}
+	}
+	return nil
+}
+
+// policy returns the auto-scaling policy of the entry, or the one in Atlas if the entry has none.
+func policy(desired, current *atlas20231115.AdvancedAutoScalingSettings) *atlas20231115.AdvancedAutoScalingSettings {
+	if desired != nil {
+		return desired
+	}
+	return current
+}
+
+func computeAutoScaled(s *atlas20231115.AdvancedAutoScalingSettings) bool {
+	return s != nil && s.Compute.GetEnabled()
+}
+
+func diskAutoScaledBy(s *atlas20231115.AdvancedAutoScalingSettings) bool {
+	return s != nil && s.DiskGB.GetEnabled()
+}
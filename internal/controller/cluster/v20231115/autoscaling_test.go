@@ -0,0 +1,161 @@
+package v20231115
+
+import (
+	"reflect"
+	"testing"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+)
+
+func autoScaling(compute, disk bool) *atlas20231115.AdvancedAutoScalingSettings {
+	return &atlas20231115.AdvancedAutoScalingSettings{
+		Compute: &atlas20231115.AdvancedComputeAutoScaling{Enabled: &compute},
+		DiskGB:  &atlas20231115.DiskGBAutoScaling{Enabled: &disk},
+	}
+}
+
+type region struct {
+	provider, name                  string
+	instanceSize, analyticsSize     string
+	autoScaling, analyticsAutoScale *atlas20231115.AdvancedAutoScalingSettings
+}
+
+func clusterDescription(diskSizeGB float64, regions ...region) *atlas20231115.AdvancedClusterDescription {
+	var configs []atlas20231115.CloudRegionConfig
+	for _, r := range regions {
+		configs = append(configs, atlas20231115.CloudRegionConfig{
+			ProviderName:         atlas20231115.PtrString(r.provider),
+			RegionName:           atlas20231115.PtrString(r.name),
+			ElectableSpecs:       &atlas20231115.HardwareSpec{InstanceSize: atlas20231115.PtrString(r.instanceSize)},
+			ReadOnlySpecs:        &atlas20231115.DedicatedHardwareSpec{InstanceSize: atlas20231115.PtrString(r.instanceSize)},
+			AnalyticsSpecs:       &atlas20231115.DedicatedHardwareSpec{InstanceSize: atlas20231115.PtrString(r.analyticsSize)},
+			AutoScaling:          r.autoScaling,
+			AnalyticsAutoScaling: r.analyticsAutoScale,
+		})
+	}
+	return &atlas20231115.AdvancedClusterDescription{
+		DiskSizeGB: &diskSizeGB,
+		ReplicationSpecs: &[]atlas20231115.ReplicationSpec{
+			{ZoneName: atlas20231115.PtrString("Zone 1"), RegionConfigs: &configs},
+		},
+	}
+}
+
+func TestWithoutAutoScaledFields(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		entry   *atlas20231115.AdvancedClusterDescription
+		current *atlas20231115.AdvancedClusterDescription
+		want    *atlas20231115.AdvancedClusterDescription
+	}{
+		{
+			name:    "no auto-scaling",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10"}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"}),
+			want:    clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10"}),
+		},
+		{
+			name:    "compute auto-scaling keeps the instance size of Atlas",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(true, false)}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"}),
+			want:    clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M10", autoScaling: autoScaling(true, false)}),
+		},
+		{
+			name:    "disk auto-scaling keeps the disk size of Atlas",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(false, true)}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"}),
+			want:    clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(false, true)}),
+		},
+		{
+			name:    "analytics auto-scaling keeps the analytics instance size of Atlas",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", analyticsAutoScale: autoScaling(true, false)}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"}),
+			want:    clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M30", analyticsAutoScale: autoScaling(true, false)}),
+		},
+		{
+			name:    "policy of Atlas applies to regions without one in the entry",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10"}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30", autoScaling: autoScaling(true, true)}),
+			want:    clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M10"}),
+		},
+		{
+			name:    "disabling auto-scaling in the entry applies its sizes",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(false, false)}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30", autoScaling: autoScaling(true, true)}),
+			want:    clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(false, false)}),
+		},
+		{
+			name:    "regions are matched by provider and name",
+			entry:   clusterDescription(10, region{provider: "AWS", name: "EU_WEST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(true, false)}),
+			current: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"}),
+			want:    clusterDescription(10, region{provider: "AWS", name: "EU_WEST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(true, false)}),
+		},
+		{
+			name: "only the auto-scaled region keeps the instance size of Atlas",
+			entry: clusterDescription(10,
+				region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10", autoScaling: autoScaling(true, false)},
+				region{provider: "GCP", name: "CENTRAL_US", instanceSize: "M10", analyticsSize: "M10"},
+			),
+			current: clusterDescription(20,
+				region{provider: "GCP", name: "CENTRAL_US", instanceSize: "M40", analyticsSize: "M40"},
+				region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M30"},
+			),
+			want: clusterDescription(10,
+				region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M10", autoScaling: autoScaling(true, false)},
+				region{provider: "GCP", name: "CENTRAL_US", instanceSize: "M10", analyticsSize: "M10"},
+			),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			withoutAutoScaledFields(tc.entry, tc.current)
+			if got, want := string(json.MustMarshal(tc.entry)), string(json.MustMarshal(tc.want)); got != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestSetAutoScalingStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cluster *atlas20231115.AdvancedClusterDescription
+		want    *AutoScalingStatus
+	}{
+		{
+			name:    "no auto-scaling",
+			cluster: clusterDescription(10, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M10", analyticsSize: "M10"}),
+		},
+		{
+			name:    "compute and disk auto-scaling",
+			cluster: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M10", autoScaling: autoScaling(true, true)}),
+			want: &AutoScalingStatus{
+				DiskSizeGB: atlas20231115.PtrFloat64(20),
+				Regions:    []AutoScalingRegion{{ZoneName: "Zone 1", ProviderName: "AWS", RegionName: "US_EAST_1", InstanceSize: "M30"}},
+			},
+		},
+		{
+			name:    "analytics auto-scaling",
+			cluster: clusterDescription(20, region{provider: "AWS", name: "US_EAST_1", instanceSize: "M30", analyticsSize: "M40", analyticsAutoScale: autoScaling(true, false)}),
+			want: &AutoScalingStatus{
+				Regions: []AutoScalingRegion{{ZoneName: "Zone 1", ProviderName: "AWS", RegionName: "US_EAST_1", AnalyticsInstanceSize: "M40"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"autoScaling": map[string]any{"diskSizeGB": 1.0}}}}
+			setAutoScalingStatus(u, tc.cluster)
+
+			_, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status", "autoScaling")
+			got := json.ConvertNestedField[AutoScalingStatus](u.Object, "status", "autoScaling")
+			switch {
+			case tc.want == nil && found:
+				t.Errorf("got %+v, want no status", got)
+			case tc.want != nil && !reflect.DeepEqual(got, tc.want):
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package v20231115
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wI2L/jsondiff"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+const (
+	// approvalAnnotation approves the disruptive changes of the generation set as its value.
+	approvalAnnotation = "mongodb.com/approve-disruptive-changes"
+	// preflightCondition reports the outcome of the pre-flight check of the last update.
+	preflightCondition = "PreflightPassed"
+)
+
+type changeClass int
+
+const (
+	// safeChange is applied by Atlas in a rolling fashion.
+	safeChange changeClass = iota
+	// disruptiveChange migrates data or removes nodes and requires approval.
+	disruptiveChange
+	// forbiddenChange is rejected by Atlas or can not be undone.
+	forbiddenChange
+)
+
+// change is a classified operation of the patch from the Atlas cluster to the entry.
+type change struct {
+	class  changeClass
+	reason string
+}
+
+var (
+	indexPattern = regexp.MustCompile(`/\d+(/|$)`)
+
+	regionConfigPath = "/replicationSpecs/*/regionConfigs/*"
+	hardwareSpecs    = []string{"electableSpecs", "readOnlySpecs", "analyticsSpecs"}
+)
+
+// classify returns the classified operation, the zero change for safe operations.
+// Removed object keys are not set in the entry and left as they are in Atlas.
+func classify(op jsondiff.Operation) change {
+	path := indexPattern.ReplaceAllString(op.Path, "/*$1")
+	isElement := strings.HasSuffix(path, "/*") || strings.HasSuffix(path, "/-")
+
+	if op.Type == jsondiff.OperationRemove && !isElement {
+		return change{}
+	}
+
+	switch {
+	case path == "/name" && op.Type == jsondiff.OperationReplace:
+		return change{forbiddenChange, "the cluster name can not be changed"}
+
+	case path == "/clusterType" && op.Type == jsondiff.OperationReplace:
+		if op.OldValue != "REPLICASET" && op.Value == "REPLICASET" {
+			return change{forbiddenChange, fmt.Sprintf("a %v cluster can not be converted to a replica set", op.OldValue)}
+		}
+		return change{disruptiveChange, fmt.Sprintf("converting a %v cluster to %v redistributes data", op.OldValue, op.Value)}
+
+	case path == "/mongoDBMajorVersion" && op.Type == jsondiff.OperationReplace:
+		if less(op.Value, op.OldValue) {
+			return change{forbiddenChange, fmt.Sprintf("MongoDB %v can not be downgraded to %v", op.OldValue, op.Value)}
+		}
+
+	// the disk usage is not part of the cluster description, so shrinks can not be checked against it here.
+	// Atlas rejects disk sizes the data does not fit into, an approved shrink fails without losing data.
+	case path == "/diskSizeGB" && op.Type == jsondiff.OperationReplace:
+		if less(op.Value, op.OldValue) {
+			return change{disruptiveChange, fmt.Sprintf("shrinking the disk from %v to %v GB fails if the data does not fit", op.OldValue, op.Value)}
+		}
+
+	case path == "/replicationSpecs/*" || path == "/replicationSpecs/-":
+		return change{disruptiveChange, "adding or removing shards redistributes data"}
+
+	case path == "/replicationSpecs/*/numShards" && op.Type == jsondiff.OperationReplace:
+		if less(op.Value, op.OldValue) {
+			return change{disruptiveChange, fmt.Sprintf("lowering numShards from %v to %v drains shards", op.OldValue, op.Value)}
+		}
+
+	case path == regionConfigPath || path == "/replicationSpecs/*/regionConfigs/-":
+		return change{disruptiveChange, "adding or removing regions migrates nodes"}
+
+	case (path == regionConfigPath+"/providerName" || path == regionConfigPath+"/backingProviderName") && op.Type == jsondiff.OperationReplace:
+		return change{disruptiveChange, fmt.Sprintf("moving from %v to %v migrates data", op.OldValue, op.Value)}
+
+	case path == regionConfigPath+"/regionName" && op.Type == jsondiff.OperationReplace:
+		return change{disruptiveChange, fmt.Sprintf("moving from region %v to %v migrates data", op.OldValue, op.Value)}
+	}
+
+	for _, specs := range hardwareSpecs {
+		prefix := regionConfigPath + "/" + specs
+		switch {
+		case path == prefix+"/nodeCount" && op.Type == jsondiff.OperationReplace && less(op.Value, op.OldValue):
+			return change{disruptiveChange, fmt.Sprintf("lowering %s.nodeCount from %v to %v removes nodes", specs, op.OldValue, op.Value)}
+		case path == prefix+"/instanceSize" && op.Type == jsondiff.OperationReplace && isNVMe(op.OldValue) != isNVMe(op.Value):
+			return change{disruptiveChange, fmt.Sprintf("changing the tier from %v to %v replaces the storage", op.OldValue, op.Value)}
+		}
+	}
+
+	return change{}
+}
+
+// preflight classifies the patch and reports the outcome in the PreflightPassed condition.
+// It returns an error for forbidden changes and for disruptive changes of a generation not approved by
+// the mongodb.com/approve-disruptive-changes annotation.
+func preflight(u *unstructured.Unstructured, patch jsondiff.Patch) error {
+	var disruptive, forbidden []string
+	for _, op := range patch {
+		c := classify(op)
+		switch c.class {
+		case disruptiveChange:
+			disruptive = append(disruptive, fmt.Sprintf("%s: %s", op.Path, c.reason))
+		case forbiddenChange:
+			forbidden = append(forbidden, fmt.Sprintf("%s: %s", op.Path, c.reason))
+		}
+	}
+
+	generation := strconv.FormatInt(u.GetGeneration(), 10)
+	condition := metav1.Condition{
+		Type:               preflightCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: u.GetGeneration(),
+		Reason:             "Safe",
+		Message:            "No disruptive changes.",
+	}
+	var err error
+	switch {
+	case len(forbidden) > 0:
+		err = fmt.Errorf("forbidden changes: %s", strings.Join(forbidden, "; "))
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "Forbidden", err.Error()
+	case len(disruptive) > 0 && u.GetAnnotations()[approvalAnnotation] != generation:
+		err = fmt.Errorf("disruptive changes require the annotation %s: %q: %s", approvalAnnotation, generation, strings.Join(disruptive, "; "))
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, "ApprovalRequired", err.Error()
+	case len(disruptive) > 0:
+		condition.Reason, condition.Message = "Approved", fmt.Sprintf("Approved disruptive changes: %s", strings.Join(disruptive, "; "))
+	}
+
+	conditions := status.GetStatus(u).Status.Conditions
+	meta.SetStatusCondition(&conditions, condition)
+	internalunstructured.SetNestedFieldSlice(u.Object, conditions, "status", "conditions")
+	return err
+}
+
+// less reports whether a is less than b, comparing numbers by value and versions like MongoDB versions
+// number by number, so 4.10 is greater than 4.2. Other values are not ordered.
+func less(a, b any) bool {
+	if x, ok := a.(float64); ok {
+		y, ok := b.(float64)
+		return ok && x < y
+	}
+	x, okA := versionNumbers(a)
+	y, okB := versionNumbers(b)
+	return okA && okB && slices.Compare(x, y) < 0
+}
+
+// versionNumbers returns the numbers of a dotted version string like "7.0".
+func versionNumbers(v any) ([]int, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	var numbers []int
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, true
+}
+
+func isNVMe(instanceSize any) bool {
+	return strings.HasSuffix(fmt.Sprint(instanceSize), "_NVME")
+}
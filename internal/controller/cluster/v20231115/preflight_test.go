@@ -0,0 +1,282 @@
+package v20231115
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wI2L/jsondiff"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+)
+
+func TestClassify(t *testing.T) {
+	replace := func(path string, oldValue, value any) jsondiff.Operation {
+		return jsondiff.Operation{Type: jsondiff.OperationReplace, Path: path, OldValue: oldValue, Value: value}
+	}
+
+	for _, tc := range []struct {
+		name string
+		op   jsondiff.Operation
+		want changeClass
+	}{
+		{
+			name: "rename",
+			op:   replace("/name", "cluster0", "cluster1"),
+			want: forbiddenChange,
+		},
+		{
+			name: "replica set to sharded",
+			op:   replace("/clusterType", "REPLICASET", "SHARDED"),
+			want: disruptiveChange,
+		},
+		{
+			name: "sharded to replica set",
+			op:   replace("/clusterType", "SHARDED", "REPLICASET"),
+			want: forbiddenChange,
+		},
+		{
+			name: "MongoDB upgrade",
+			op:   replace("/mongoDBMajorVersion", "6.0", "7.0"),
+			want: safeChange,
+		},
+		{
+			name: "MongoDB downgrade",
+			op:   replace("/mongoDBMajorVersion", "7.0", "6.0"),
+			want: forbiddenChange,
+		},
+		{
+			name: "MongoDB upgrade to a two digit minor version",
+			op:   replace("/mongoDBMajorVersion", "4.2", "4.10"),
+			want: safeChange,
+		},
+		{
+			name: "MongoDB downgrade from a two digit minor version",
+			op:   replace("/mongoDBMajorVersion", "4.10", "4.2"),
+			want: forbiddenChange,
+		},
+		{
+			name: "growing disk",
+			op:   replace("/diskSizeGB", 10.0, 20.0),
+			want: safeChange,
+		},
+		{
+			name: "shrinking disk",
+			op:   replace("/diskSizeGB", 20.0, 10.5),
+			want: disruptiveChange,
+		},
+		{
+			name: "adding a shard",
+			op:   jsondiff.Operation{Type: jsondiff.OperationAdd, Path: "/replicationSpecs/-", Value: map[string]any{}},
+			want: disruptiveChange,
+		},
+		{
+			name: "removing a shard",
+			op:   jsondiff.Operation{Type: jsondiff.OperationRemove, Path: "/replicationSpecs/1"},
+			want: disruptiveChange,
+		},
+		{
+			name: "lowering numShards",
+			op:   replace("/replicationSpecs/0/numShards", 3.0, 2.0),
+			want: disruptiveChange,
+		},
+		{
+			name: "raising numShards",
+			op:   replace("/replicationSpecs/0/numShards", 2.0, 3.0),
+			want: safeChange,
+		},
+		{
+			name: "adding a region",
+			op:   jsondiff.Operation{Type: jsondiff.OperationAdd, Path: "/replicationSpecs/0/regionConfigs/2", Value: map[string]any{}},
+			want: disruptiveChange,
+		},
+		{
+			name: "moving region in a later replication spec",
+			op:   replace("/replicationSpecs/12/regionConfigs/3/regionName", "US_EAST_1", "EU_WEST_1"),
+			want: disruptiveChange,
+		},
+		{
+			name: "moving provider",
+			op:   replace("/replicationSpecs/0/regionConfigs/0/providerName", "AWS", "GCP"),
+			want: disruptiveChange,
+		},
+		{
+			name: "lowering nodeCount",
+			op:   replace("/replicationSpecs/0/regionConfigs/0/electableSpecs/nodeCount", 5.0, 3.0),
+			want: disruptiveChange,
+		},
+		{
+			name: "raising nodeCount",
+			op:   replace("/replicationSpecs/0/regionConfigs/0/readOnlySpecs/nodeCount", 0.0, 2.0),
+			want: safeChange,
+		},
+		{
+			name: "scaling the tier",
+			op:   replace("/replicationSpecs/0/regionConfigs/0/electableSpecs/instanceSize", "M10", "M30"),
+			want: safeChange,
+		},
+		{
+			name: "switching to NVMe",
+			op:   replace("/replicationSpecs/0/regionConfigs/0/electableSpecs/instanceSize", "M40", "M40_NVME"),
+			want: disruptiveChange,
+		},
+		{
+			name: "removed key is left as it is",
+			op:   jsondiff.Operation{Type: jsondiff.OperationRemove, Path: "/replicationSpecs/0/regionConfigs/0/priority"},
+			want: safeChange,
+		},
+		{
+			name: "label element removed",
+			op:   jsondiff.Operation{Type: jsondiff.OperationRemove, Path: "/labels/0"},
+			want: safeChange,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classify(tc.op); got.class != tc.want {
+				t.Errorf("got class %d (%s), want %d", got.class, got.reason, tc.want)
+			}
+		})
+	}
+}
+
+func TestLess(t *testing.T) {
+	for _, tc := range []struct {
+		a, b any
+		want bool
+	}{
+		{a: 1.0, b: 2.0, want: true},
+		{a: 2.0, b: 1.0},
+		{a: 2.0, b: 2.0},
+		{a: 10.5, b: 10.75, want: true},
+		{a: "6.0", b: "7.0", want: true},
+		{a: "7.0", b: "6.0"},
+		{a: "7.0", b: "7.0"},
+		{a: "4.2", b: "4.10", want: true},
+		{a: "4.10", b: "4.2"},
+		{a: "7", b: "7.0", want: true},
+		{a: "7.0", b: "8.0-rc0"},
+		{a: "M10", b: "M20"},
+		{a: 1.0, b: "2"},
+		{a: nil, b: 1.0},
+	} {
+		if got := less(tc.a, tc.b); got != tc.want {
+			t.Errorf("less(%#v, %#v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	current := map[string]any{
+		"name":                "cluster0",
+		"mongoDBMajorVersion": "7.0",
+		"diskSizeGB":          40.0,
+		"replicationSpecs": []any{map[string]any{
+			"regionConfigs": []any{map[string]any{
+				"providerName":   "AWS",
+				"regionName":     "US_EAST_1",
+				"electableSpecs": map[string]any{"instanceSize": "M10", "nodeCount": int64(3)},
+			}},
+		}},
+	}
+	withRegion := func(region string) map[string]any {
+		entry := runtime.DeepCopyJSON(current)
+		spec := entry["replicationSpecs"].([]any)[0].(map[string]any)
+		spec["regionConfigs"].([]any)[0].(map[string]any)["regionName"] = region
+		return entry
+	}
+	withField := func(key string, value any) map[string]any {
+		entry := runtime.DeepCopyJSON(current)
+		entry[key] = value
+		return entry
+	}
+
+	for _, tc := range []struct {
+		name       string
+		entry      map[string]any
+		approval   string
+		wantErr    string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "safe",
+			entry:      withField("mongoDBMajorVersion", "8.0"),
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Safe",
+		},
+		{
+			name:       "disruptive without approval",
+			entry:      withRegion("EU_WEST_1"),
+			wantErr:    `disruptive changes require the annotation mongodb.com/approve-disruptive-changes: "2"`,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ApprovalRequired",
+		},
+		{
+			name:       "disruptive approved for another generation",
+			entry:      withRegion("EU_WEST_1"),
+			approval:   "1",
+			wantErr:    "/replicationSpecs/0/regionConfigs/0/regionName: moving from region US_EAST_1 to EU_WEST_1 migrates data",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ApprovalRequired",
+		},
+		{
+			name:       "disruptive approved",
+			entry:      withRegion("EU_WEST_1"),
+			approval:   "2",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Approved",
+		},
+		{
+			name:       "shrinking disk without approval",
+			entry:      withField("diskSizeGB", 20.0),
+			wantErr:    "/diskSizeGB: shrinking the disk from 40 to 20 GB fails if the data does not fit",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ApprovalRequired",
+		},
+		{
+			name:       "forbidden although approved",
+			entry:      withField("name", "cluster1"),
+			approval:   "2",
+			wantErr:    "forbidden changes: /name: the cluster name can not be changed",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Forbidden",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]any{}}
+			u.SetGeneration(2)
+			if tc.approval != "" {
+				u.SetAnnotations(map[string]string{approvalAnnotation: tc.approval})
+			}
+
+			patch, err := jsondiff.Compare(current, tc.entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = preflight(u, patch)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("got error %v, want error containing %q", err, tc.wantErr)
+			}
+
+			condition := meta.FindStatusCondition(status.GetStatus(u).Status.Conditions, preflightCondition)
+			if condition == nil {
+				t.Fatal("missing condition")
+			}
+			if condition.Reason != tc.wantReason {
+				t.Errorf("got reason %s, want %s", condition.Reason, tc.wantReason)
+			}
+			if condition.Status != tc.wantStatus {
+				t.Errorf("got status %s, want %s", condition.Status, tc.wantStatus)
+			}
+			if condition.ObservedGeneration != 2 {
+				t.Errorf("got observed generation %d, want 2", condition.ObservedGeneration)
+			}
+		})
+	}
+}
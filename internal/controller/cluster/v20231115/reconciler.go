@@ -2,233 +2,222 @@ package v20231115
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	"github.com/wI2L/jsondiff"
 	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
-	ctrlstate "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/result"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
-	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
 )
 
-type Reconciler struct {
-	ctrlstate.StateReconciler
-	Client client.Client
-}
-
-func (r *Reconciler) HandleImportRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	externalName, ok := u.GetAnnotations()["mongodb.com/external-name"]
-	if !ok {
-		return result.Error(state.StateImportRequested, errors.New("missing mongodb.com/external-name"))
-	}
-
-	externalGroupID, ok := u.GetAnnotations()["mongodb.com/external-group-id"]
-	if !ok {
-		return result.Error(state.StateImportRequested, errors.New("missing mongodb.com/external-group-id"))
-	}
-
-	response, _, err := atlasClients.SdkClient20231115008.ClustersApi.GetCluster(ctx, externalGroupID, externalName).Execute()
+const version = "v20231115"
+
+// Reconciler manages a dedicated cluster.
+//
+// spec.v20231115.pauseSchedule optionally pauses and resumes the cluster at the times of its cron expressions,
+// overriding spec.v20231115.entry.paused. Actions within an hour of the previous one are postponed as Atlas
+// rejects them, the next planned action is reported in status.pauseSchedule and the cluster is reconciled again
+// at its time.
+//
+// Updates are checked before they are sent to Atlas and reported in the PreflightPassed condition.
+// Changes migrating data or removing nodes, i.e. moving regions or lowering numShards, are only applied if the
+// mongodb.com/approve-disruptive-changes annotation is set to the generation to apply. Changes Atlas rejects,
+// i.e. renames or MongoDB downgrades, are not sent.
+//
+// Instance and disk sizes managed by active auto-scaling policies are left as they are in Atlas, so updates don't
+// revert scaling. The sizes Atlas chose are reported in status.autoScaling.
+//
+// Clusters created by a FlexCluster upgrading to them, marked by the mongodb.com/upgrade-from-flex-cluster
+// annotation, upgrade the flex cluster of the same name through the flex cluster upgrade API instead of creating
+// a new one and apply the rest of the entry once the upgrade finished. The progress is reported in the
+// FlexClusterUpgraded condition.
+//
+// spec.v20231115.advancedConfiguration holds the process arguments of the cluster, i.e. the oplog size or the
+// minimum TLS version. They are applied through their own endpoint once the cluster is IDLE and set back with
+// each reconciliation if they were changed in Atlas.
+type Reconciler = generic.Reconciler[atlas20231115.GetClusterApiParams, atlas20231115.AdvancedClusterDescription]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "cluster",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetClusterApiParams, error) {
+			externalName, err := generic.Annotation(annotations, "mongodb.com/external-name")
+			if err != nil {
+				return atlas20231115.GetClusterApiParams{}, err
+			}
+			externalGroupID, err := generic.Annotation(annotations, "mongodb.com/external-group-id")
+			if err != nil {
+				return atlas20231115.GetClusterApiParams{}, err
+			}
+			return atlas20231115.GetClusterApiParams{GroupId: externalGroupID, ClusterName: externalName}, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetClusterApiParams {
+			return atlas20231115.GetClusterApiParams{
+				GroupId:     generic.Status[atlas20231115.AdvancedClusterDescription](u, version).GetGroupId(),
+				ClusterName: generic.Entry[atlas20231115.AdvancedClusterDescription](u, version).GetName(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.AdvancedClusterDescription, error) {
+			params := generic.Params[atlas20231115.CreateClusterApiParams](u, version)
+			params.AdvancedClusterDescription = generic.Entry[atlas20231115.AdvancedClusterDescription](u, version)
+			if _, ok := u.GetAnnotations()[upgradeAnnotation]; ok {
+				return upgradeFlexCluster(ctx, cs, u, params.GroupId, params.AdvancedClusterDescription)
+			}
+			if err := checkEncryptionAtRest(ctx, cs, params.GroupId, params.AdvancedClusterDescription.GetEncryptionAtRestProvider()); err != nil {
+				return nil, err
+			}
+			response, _, err := cs.SdkClient20231115008.ClustersApi.CreateClusterWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetClusterApiParams) (*atlas20231115.AdvancedClusterDescription, error) {
+			response, _, err := cs.SdkClient20231115008.ClustersApi.GetClusterWithParams(ctx, &id).Execute()
+			if atlas20231115.IsErrorCode(err, "CANNOT_USE_FLEX_CLUSTER_IN_CLUSTER_API") {
+				// flex clusters being upgraded are served by the flex cluster API until the upgrade finished.
+				return &atlas20231115.AdvancedClusterDescription{
+					GroupId:   &id.GroupId,
+					Name:      &id.ClusterName,
+					StateName: atlas20231115.PtrString("UPDATING"),
+				}, nil
+			}
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetClusterApiParams, u *unstructured.Unstructured) (*atlas20231115.AdvancedClusterDescription, error) {
+			entry := generic.Entry[atlas20231115.AdvancedClusterDescription](u, version)
+			current := generic.Status[atlas20231115.AdvancedClusterDescription](u, version)
+
+			if provider := entry.GetEncryptionAtRestProvider(); provider != current.GetEncryptionAtRestProvider() {
+				if err := checkEncryptionAtRest(ctx, cs, id.GroupId, provider); err != nil {
+					return nil, err
+				}
+			}
+
+			now := time.Now()
+			action, err := duePauseAction(u, now)
+			if err != nil {
+				return nil, err
+			}
+			entry.Paused = scheduledPaused(u, entry, action)
+			withoutAutoScaledFields(entry, current)
+
+			patch, err := jsondiff.CompareJSON(json.MustMarshal(current), json.MustMarshal(entry))
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare cluster: %w", err)
+			}
+			logChanges(ctx, patch)
+			if err := preflight(u, patch); err != nil {
+				return nil, err
+			}
+
+			params := &atlas20231115.UpdateClusterApiParams{
+				GroupId:                    id.GroupId,
+				ClusterName:                id.ClusterName,
+				AdvancedClusterDescription: entry,
+			}
+			response, _, err := cs.SdkClient20231115008.ClustersApi.UpdateClusterWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			if action != "" {
+				recordPauseAction(u, action, now)
+			}
+			setAutoScalingStatus(u, response)
+			completeUpgrade(u)
+			return response, nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetClusterApiParams) error {
+			params := &atlas20231115.DeleteClusterApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+			}
+			_, err := cs.SdkClient20231115008.ClustersApi.DeleteClusterWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND")
+		},
+
+		// scheduled pauses and resumes and the entry of clusters upgraded from flex clusters are applied
+		// although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			setAutoScalingStatus(u, generic.Status[atlas20231115.AdvancedClusterDescription](u, version))
+			if upgradePending(u) != "" {
+				return true, nil
+			}
+			action, err := duePauseAction(u, time.Now())
+			return action != "", err
+		},
+
+		Pending: func(u *unstructured.Unstructured, _ *atlas20231115.AdvancedClusterDescription) string {
+			return upgradePending(u)
+		},
+
+		RequeueAfter: func(u *unstructured.Unstructured) time.Duration {
+			if after := nextPauseActionAfter(u); after > 0 && after < generic.ResyncInterval {
+				return after
+			}
+			return generic.ResyncInterval
+		},
+
+		StateName:  (*atlas20231115.AdvancedClusterDescription).GetStateName,
+		BusyStates: []string{"CREATING", "UPDATING", "REPAIRING"},
+
+		Sections: []generic.Section[atlas20231115.GetClusterApiParams]{
+			{Field: "advancedConfiguration", Condition: "AdvancedConfigurationReady", Apply: applyAdvancedConfiguration},
+		},
+	})
+}
+
+// checkEncryptionAtRest returns an error unless customer key management for the given encryption at rest provider
+// is enabled in the project and Atlas validated the key. Clusters using a provider are only created or switched
+// to it after the project's EncryptionAtRest settings have been applied.
+func checkEncryptionAtRest(ctx context.Context, cs *atlas.ClientSet, groupID, provider string) error {
+	if provider == "" || provider == "NONE" {
+		return nil
+	}
+
+	params := &atlas20231115.GetEncryptionAtRestApiParams{GroupId: groupID}
+	config, _, err := cs.SdkClient20231115008.EncryptionAtRestUsingCustomerKeyManagementApi.GetEncryptionAtRestWithParams(ctx, params).Execute()
 	if err != nil {
-		return result.Error(state.StateImportRequested, fmt.Errorf("failed to get group: %w", err))
+		return fmt.Errorf("failed to get encryption at rest settings: %w", err)
 	}
 
-	internalunstructured.SetNestedFieldObject(u.Object, response, "spec", "v20231115", "entry")
-
-	err = r.Client.Patch(ctx, u, client.RawPatch(types.MergePatchType, json.MustMarshal(u.Object)))
-	if err != nil {
-		return result.Error(state.StateImportRequested, fmt.Errorf("failed to patch cluster: %w", err))
+	var enabled, valid bool
+	switch provider {
+	case "AWS":
+		enabled, valid = config.AwsKms.GetEnabled(), config.AwsKms.GetValid()
+	case "AZURE":
+		enabled, valid = config.AzureKeyVault.GetEnabled(), config.AzureKeyVault.GetValid()
+	case "GCP":
+		enabled, valid = config.GoogleCloudKms.GetEnabled(), config.GoogleCloudKms.GetValid()
 	}
 
-	setStatus(u, response)
-
-	return result.NextState(state.StateImported, "Cluster imported")
-}
-
-func (r *Reconciler) HandleInitial(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	params := getParams(u)
-	params.AdvancedClusterDescription = getEntry(u)
-
-	response, _, err := atlasClients.SdkClient20231115008.ClustersApi.CreateClusterWithParams(ctx, params).Execute()
-	if err != nil {
-		return result.Error(state.StateInitial, fmt.Errorf("failed to create cluster: %w", err))
-	}
-
-	setStatus(u, response)
-
-	return result.NextState(state.StateCreating, "Creating cluster")
-}
-
-func (r *Reconciler) HandleImported(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateUpdated)
-}
-
-func (r *Reconciler) HandleCreated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateUpdated)
-}
-
-func (r *Reconciler) HandleUpdated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateUpdated)
-}
-
-func (r *Reconciler) HandleIdle(ctx context.Context, u *unstructured.Unstructured, finalState state.ResourceState) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	response, err := r.updateStatus(ctx, u)
-	if err != nil {
-		return result.Error(finalState, fmt.Errorf("failed to update status: %w", err))
-	}
-
-	if response.GetStateName() != "IDLE" {
-		return result.NextState(state.StateUpdating, "Updating cluster")
-	}
-
-	st := status.GetStatus(u)
-	currentState := meta.FindStatusCondition(st.Status.Conditions, state.StateCondition)
-	currentReady := meta.FindStatusCondition(st.Status.Conditions, state.ReadyCondition)
-	if currentState.ObservedGeneration == u.GetGeneration() && currentReady.Reason != ctrlstate.ReadyReasonError {
-		return result.NextState(finalState, "Upserted cluster")
-	}
-
-	entry := getEntry(u)
-	params := &atlas20231115.UpdateClusterApiParams{
-		GroupId:                    getStatus(u).GetGroupId(),
-		ClusterName:                entry.GetName(),
-		AdvancedClusterDescription: entry,
-	}
-
-	r.logChanges(ctx, entry, response)
-
-	response, _, err = atlasClients.SdkClient20231115008.ClustersApi.UpdateClusterWithParams(ctx, params).Execute()
-	if err != nil {
-		return result.Error(state.StateUpdating, fmt.Errorf("failed to update cluster: %w", err))
+	switch {
+	case !enabled:
+		return fmt.Errorf("encryption at rest using %s is not enabled in project %s", provider, groupID)
+	case !valid:
+		return fmt.Errorf("encryption at rest key of %s is not valid in project %s", provider, groupID)
 	}
-	setStatus(u, response)
-
-	return result.NextState(state.StateUpdating, "Updating cluster")
+	return nil
 }
 
-func (r *Reconciler) logChanges(ctx context.Context, ako, atlas *atlas20231115.AdvancedClusterDescription) {
-	p, err := jsondiff.CompareJSON(json.MustMarshal(ako), json.MustMarshal(atlas))
-	if err != nil {
-		return
-	}
+func logChanges(ctx context.Context, patch jsondiff.Patch) {
 	logger := log.FromContext(ctx).WithName("cluster-controller")
 
-	for _, op := range p {
+	for _, op := range patch {
 		logger.Info("patch", "op", op.String())
 	}
 }
-
-func (r *Reconciler) HandleUpserting(ctx context.Context, u *unstructured.Unstructured, currentState, finalState state.ResourceState) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	params := &atlas20231115.GetClusterApiParams{
-		GroupId:     getStatus(u).GetGroupId(),
-		ClusterName: getEntry(u).GetName(),
-	}
-
-	response, _, err := atlasClients.SdkClient20231115008.ClustersApi.GetClusterWithParams(ctx, params).Execute()
-	if err != nil {
-		return result.Error(currentState, fmt.Errorf("failed to get cluster: %w", err))
-	}
-
-	setStatus(u, response)
-
-	if response.GetStateName() == "CREATING" || response.GetStateName() == "UPDATING" {
-		return result.NextState(currentState, "Upserting cluster")
-	}
-
-	return result.NextState(finalState, "Upserted cluster")
-}
-
-func (r *Reconciler) HandleCreating(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleUpserting(ctx, u, state.StateCreating, state.StateCreated)
-}
-
-func (r *Reconciler) HandleUpdating(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleUpserting(ctx, u, state.StateUpdating, state.StateUpdated)
-}
-
-func (r *Reconciler) HandleDeletionRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	params := &atlas20231115.DeleteClusterApiParams{
-		GroupId:     getStatus(u).GetGroupId(),
-		ClusterName: getEntry(u).GetName(),
-	}
-
-	_, err := atlasClients.SdkClient20231115008.ClustersApi.DeleteClusterWithParams(ctx, params).Execute()
-	switch {
-	case atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND"):
-		return result.NextState(state.StateDeleted, "Cluster has been deleted in Atlas.")
-	case err != nil:
-		return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to delete cluster: %w", err))
-	}
-
-	_, err = r.updateStatus(ctx, u)
-	if err != nil {
-		return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to update status: %w", err))
-	}
-
-	return result.NextState(state.StateDeleting, "Deleting cluster")
-}
-
-func (r *Reconciler) HandleDeleting(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	_, err := r.updateStatus(ctx, u)
-
-	switch {
-	case atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND"):
-		return result.NextState(state.StateDeleted, "Cluster has been deleted in Atlas.")
-	case err != nil:
-		return result.Error(state.StateDeleting, fmt.Errorf("failed to update status: %w", err))
-	}
-
-	return result.NextState(state.StateDeleting, "Deleting cluster")
-}
-
-func (r *Reconciler) updateStatus(ctx context.Context, u *unstructured.Unstructured) (*atlas20231115.AdvancedClusterDescription, error) {
-	atlasClients := atlas.FromContext(ctx)
-
-	params := &atlas20231115.GetClusterApiParams{
-		GroupId:     getStatus(u).GetGroupId(),
-		ClusterName: getEntry(u).GetName(),
-	}
-
-	response, _, err := atlasClients.SdkClient20231115008.ClustersApi.GetClusterWithParams(ctx, params).Execute()
-	if err == nil {
-		setStatus(u, response)
-	}
-
-	return response, err
-}
-
-func getParams(u *unstructured.Unstructured) *atlas20231115.CreateClusterApiParams {
-	return json.ConvertNestedField[atlas20231115.CreateClusterApiParams](u.Object, "spec", "v20231115", "parameters")
-}
-
-func getEntry(u *unstructured.Unstructured) *atlas20231115.AdvancedClusterDescription {
-	return json.ConvertNestedField[atlas20231115.AdvancedClusterDescription](u.Object, "spec", "v20231115", "entry")
-}
-
-func getStatus(u *unstructured.Unstructured) *atlas20231115.AdvancedClusterDescription {
-	return json.ConvertNestedField[atlas20231115.AdvancedClusterDescription](u.Object, "status", "v20231115")
-}
-
-func setStatus(u *unstructured.Unstructured, s *atlas20231115.AdvancedClusterDescription) {
-	internalunstructured.SetNestedFieldObject(u.Object, s, "status", "v20231115")
-}
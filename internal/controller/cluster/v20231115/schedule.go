@@ -0,0 +1,183 @@
+package v20231115
+
+import (
+	"fmt"
+	"time"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/cron"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+const (
+	pauseAction  = "PAUSE"
+	resumeAction = "RESUME"
+
+	// minPauseInterval is the minimum time Atlas requires between pausing and resuming a cluster.
+	minPauseInterval = 60 * time.Minute
+	// pauseLookback is how far back the schedule is evaluated the first time,
+	// so a newly scheduled cluster is brought into the phase it is in right away.
+	pauseLookback = 7 * 24 * time.Hour
+)
+
+// PauseSchedule is spec.<version>.pauseSchedule.
+type PauseSchedule struct {
+	Pause    string `json:"pause"`
+	Resume   string `json:"resume"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// PauseScheduleStatus is status.pauseSchedule.
+type PauseScheduleStatus struct {
+	LastAction     string     `json:"lastAction,omitempty"`
+	LastActionTime *time.Time `json:"lastActionTime,omitempty"`
+	NextAction     string     `json:"nextAction,omitempty"`
+	NextActionTime *time.Time `json:"nextActionTime,omitempty"`
+	CheckTime      *time.Time `json:"checkTime,omitempty"`
+}
+
+// duePauseAction evaluates the pause schedule of u between the last check and now and returns the action due,
+// PAUSE or RESUME, or an empty string if the cluster is in the scheduled phase already. Actions within
+// minPauseInterval of the last action are postponed. The next planned action is recorded in status.pauseSchedule.
+func duePauseAction(u *unstructured.Unstructured, now time.Time) (string, error) {
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", version, "pauseSchedule"); !found {
+		unstructured.RemoveNestedField(u.Object, "status", "pauseSchedule")
+		return "", nil
+	}
+
+	pause, resume, loc, err := parsePauseSchedule(generic.SectionValue[PauseSchedule](u, version, "pauseSchedule"))
+	if err != nil {
+		return "", err
+	}
+	status := json.ConvertNestedField[PauseScheduleStatus](u.Object, "status", "pauseSchedule")
+	now = now.In(loc)
+
+	checkTime := now.Add(-pauseLookback)
+	if status.CheckTime != nil {
+		checkTime = status.CheckTime.In(loc)
+	}
+
+	// the latest scheduled time since the last check defines the phase the cluster is supposed to be in.
+	lastPause, lastResume := last(pause, checkTime, now), last(resume, checkTime, now)
+	action := ""
+	switch {
+	case lastPause.IsZero() && lastResume.IsZero():
+	case lastPause.After(lastResume):
+		action = pauseAction
+	default:
+		action = resumeAction
+	}
+
+	paused := generic.Status[atlas20231115.AdvancedClusterDescription](u, version).GetPaused()
+	if action != "" && (action == pauseAction) == paused {
+		status.LastAction = action
+		action = ""
+	}
+
+	earliest := now
+	if status.LastActionTime != nil {
+		earliest = status.LastActionTime.Add(minPauseInterval)
+	}
+
+	switch {
+	case action != "" && now.Before(earliest):
+		// keep the check time so the action stays due.
+		status.NextAction, status.NextActionTime = action, &earliest
+		setPauseScheduleStatus(u, status)
+		return "", nil
+	case action != "":
+		status.NextAction, status.NextActionTime = nextPauseAction(pause, resume, now, earliest)
+		setPauseScheduleStatus(u, status)
+		return action, nil
+	}
+
+	status.CheckTime = &now
+	status.NextAction, status.NextActionTime = nextPauseAction(pause, resume, now, earliest)
+	setPauseScheduleStatus(u, status)
+	return "", nil
+}
+
+// recordPauseAction records the action applied to Atlas at now in status.pauseSchedule.
+func recordPauseAction(u *unstructured.Unstructured, action string, now time.Time) {
+	status := json.ConvertNestedField[PauseScheduleStatus](u.Object, "status", "pauseSchedule")
+	status.LastAction = action
+	status.LastActionTime = &now
+	status.CheckTime = &now
+	if status.NextActionTime != nil && status.NextActionTime.Before(now.Add(minPauseInterval)) {
+		earliest := now.Add(minPauseInterval)
+		status.NextActionTime = &earliest
+	}
+	setPauseScheduleStatus(u, status)
+}
+
+// nextPauseActionAfter returns the time until the next action recorded in status.pauseSchedule, or zero if none is planned.
+// Overdue actions are reconciled again right away.
+func nextPauseActionAfter(u *unstructured.Unstructured) time.Duration {
+	status := json.ConvertNestedField[PauseScheduleStatus](u.Object, "status", "pauseSchedule")
+	if status.NextActionTime == nil {
+		return 0
+	}
+	return max(time.Until(*status.NextActionTime), time.Second)
+}
+
+// scheduledPaused returns the paused flag to send to Atlas, the one of the action due or last applied by the
+// schedule if any, otherwise the one of the entry.
+func scheduledPaused(u *unstructured.Unstructured, entry *atlas20231115.AdvancedClusterDescription, action string) *bool {
+	if action == "" {
+		action = json.ConvertNestedField[PauseScheduleStatus](u.Object, "status", "pauseSchedule").LastAction
+	}
+	if action == "" {
+		return entry.Paused
+	}
+	return atlas20231115.PtrBool(action == pauseAction)
+}
+
+func parsePauseSchedule(s *PauseSchedule) (*cron.Schedule, *cron.Schedule, *time.Location, error) {
+	pause, err := cron.Parse(s.Pause)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pauseSchedule.pause: %w", err)
+	}
+	resume, err := cron.Parse(s.Resume)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pauseSchedule.resume: %w", err)
+	}
+	loc := time.UTC
+	if s.TimeZone != "" {
+		if loc, err = time.LoadLocation(s.TimeZone); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid pauseSchedule.timeZone: %w", err)
+		}
+	}
+	return pause, resume, loc, nil
+}
+
+// last returns the latest time of the schedule after from and not after to, or the zero time.
+func last(s *cron.Schedule, from, to time.Time) time.Time {
+	var result time.Time
+	for t := s.Next(from); !t.IsZero() && !t.After(to); t = s.Next(t) {
+		result = t
+	}
+	return result
+}
+
+// nextPauseAction returns the action scheduled next after now, not before earliest.
+func nextPauseAction(pause, resume *cron.Schedule, now, earliest time.Time) (string, *time.Time) {
+	action, next := pauseAction, pause.Next(now)
+	if r := resume.Next(now); !r.IsZero() && (next.IsZero() || r.Before(next)) {
+		action, next = resumeAction, r
+	}
+	if next.IsZero() {
+		return "", nil
+	}
+	if next.Before(earliest) {
+		next = earliest
+	}
+	return action, &next
+}
+
+func setPauseScheduleStatus(u *unstructured.Unstructured, status *PauseScheduleStatus) {
+	internalunstructured.SetNestedFieldObject(u.Object, status, "status", "pauseSchedule")
+}
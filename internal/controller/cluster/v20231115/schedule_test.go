@@ -0,0 +1,223 @@
+package v20231115
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+func scheduledCluster(schedule map[string]any, paused bool, status *PauseScheduleStatus) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec":   map[string]any{version: map[string]any{"entry": map[string]any{"name": "cluster0"}}},
+		"status": map[string]any{version: map[string]any{"name": "cluster0", "paused": paused}},
+	}}
+	if schedule != nil {
+		internalunstructured.SetNestedFieldObject(u.Object, schedule, "spec", version, "pauseSchedule")
+	}
+	if status != nil {
+		setPauseScheduleStatus(u, status)
+	}
+	return u
+}
+
+func at(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestDuePauseAction(t *testing.T) {
+	nightly := map[string]any{"pause": "0 22 * * *", "resume": "0 6 * * *"}
+
+	for _, tc := range []struct {
+		name           string
+		schedule       map[string]any
+		paused         bool
+		status         *PauseScheduleStatus
+		now            string
+		want           string
+		wantErr        bool
+		wantNextAction string
+		wantNextTime   *time.Time
+		wantCheckTime  *time.Time
+	}{
+		{
+			name: "no schedule",
+			now:  "2024-03-05T23:00:00Z",
+		},
+		{
+			name:           "first evaluation pauses a running cluster in the pause phase",
+			schedule:       nightly,
+			now:            "2024-03-05T23:00:00Z",
+			want:           pauseAction,
+			wantNextAction: resumeAction,
+			wantNextTime:   at("2024-03-06T06:00:00Z"),
+		},
+		{
+			name:           "first evaluation keeps a paused cluster in the pause phase",
+			schedule:       nightly,
+			paused:         true,
+			now:            "2024-03-05T23:00:00Z",
+			wantNextAction: resumeAction,
+			wantNextTime:   at("2024-03-06T06:00:00Z"),
+			wantCheckTime:  at("2024-03-05T23:00:00Z"),
+		},
+		{
+			name:           "first evaluation resumes a paused cluster in the resume phase",
+			schedule:       nightly,
+			paused:         true,
+			now:            "2024-03-05T12:00:00Z",
+			want:           resumeAction,
+			wantNextAction: pauseAction,
+			wantNextTime:   at("2024-03-05T22:00:00Z"),
+		},
+		{
+			name:           "nothing scheduled since the last check",
+			schedule:       nightly,
+			status:         &PauseScheduleStatus{LastAction: resumeAction, CheckTime: at("2024-03-05T11:59:00Z")},
+			now:            "2024-03-05T12:00:00Z",
+			wantNextAction: pauseAction,
+			wantNextTime:   at("2024-03-05T22:00:00Z"),
+			wantCheckTime:  at("2024-03-05T12:00:00Z"),
+		},
+		{
+			name:           "action since the last check is due",
+			schedule:       nightly,
+			status:         &PauseScheduleStatus{LastAction: resumeAction, LastActionTime: at("2024-03-05T06:00:00Z"), CheckTime: at("2024-03-05T21:59:00Z")},
+			now:            "2024-03-05T22:00:00Z",
+			want:           pauseAction,
+			wantNextAction: resumeAction,
+			wantNextTime:   at("2024-03-06T06:00:00Z"),
+			wantCheckTime:  at("2024-03-05T21:59:00Z"),
+		},
+		{
+			name:           "action within an hour of the last one is postponed",
+			schedule:       nightly,
+			status:         &PauseScheduleStatus{LastAction: resumeAction, LastActionTime: at("2024-03-05T21:40:00Z"), CheckTime: at("2024-03-05T21:50:00Z")},
+			now:            "2024-03-05T22:10:00Z",
+			wantNextAction: pauseAction,
+			wantNextTime:   at("2024-03-05T22:40:00Z"),
+			wantCheckTime:  at("2024-03-05T21:50:00Z"),
+		},
+		{
+			name:           "schedule is evaluated in its time zone",
+			schedule:       map[string]any{"pause": "0 22 * * *", "resume": "0 6 * * *", "timeZone": "Europe/Berlin"},
+			status:         &PauseScheduleStatus{CheckTime: at("2024-03-05T20:50:00Z")},
+			now:            "2024-03-05T21:10:00Z",
+			want:           pauseAction,
+			wantNextAction: resumeAction,
+			wantNextTime:   at("2024-03-06T05:00:00Z"),
+			wantCheckTime:  at("2024-03-05T20:50:00Z"),
+		},
+		{
+			name:     "invalid expression",
+			schedule: map[string]any{"pause": "0 22 * *", "resume": "0 6 * * *"},
+			now:      "2024-03-05T12:00:00Z",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid time zone",
+			schedule: map[string]any{"pause": "0 22 * * *", "resume": "0 6 * * *", "timeZone": "Mars/Olympus"},
+			now:      "2024-03-05T12:00:00Z",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := scheduledCluster(tc.schedule, tc.paused, tc.status)
+
+			got, err := duePauseAction(u, *at(tc.now))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got action %q, want %q", got, tc.want)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			status := json.ConvertNestedField[PauseScheduleStatus](u.Object, "status", "pauseSchedule")
+			if status.NextAction != tc.wantNextAction {
+				t.Errorf("got next action %q, want %q", status.NextAction, tc.wantNextAction)
+			}
+			if !sameTime(status.NextActionTime, tc.wantNextTime) {
+				t.Errorf("got next action time %v, want %v", status.NextActionTime, tc.wantNextTime)
+			}
+			if tc.wantCheckTime != nil && !sameTime(status.CheckTime, tc.wantCheckTime) {
+				t.Errorf("got check time %v, want %v", status.CheckTime, tc.wantCheckTime)
+			}
+		})
+	}
+}
+
+// TestSettledClusterWakesUp checks that a cluster in its scheduled phase is reconciled again at its next action.
+func TestSettledClusterWakesUp(t *testing.T) {
+	u := scheduledCluster(map[string]any{"pause": "0 22 * * *", "resume": "0 6 * * *"}, false, nil)
+	if action, err := duePauseAction(u, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if action != "" {
+		// the cluster is in the pause phase, settle it there.
+		recordPauseAction(u, action, time.Now())
+		if _, err := duePauseAction(u, time.Now()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := nextPauseActionAfter(u)
+	if got <= 0 || got > 24*time.Hour {
+		t.Errorf("got requeue after %v, want the time until the next action within a day", got)
+	}
+}
+
+func TestNextPauseActionAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status *PauseScheduleStatus
+		min    time.Duration
+		max    time.Duration
+	}{
+		{
+			name: "no schedule",
+		},
+		{
+			name:   "nothing planned",
+			status: &PauseScheduleStatus{LastAction: pauseAction},
+		},
+		{
+			name:   "planned action",
+			status: &PauseScheduleStatus{NextAction: resumeAction, NextActionTime: ptr(time.Now().Add(time.Hour))},
+			min:    59 * time.Minute,
+			max:    time.Hour,
+		},
+		{
+			name:   "overdue action",
+			status: &PauseScheduleStatus{NextAction: resumeAction, NextActionTime: ptr(time.Now().Add(-time.Hour))},
+			min:    time.Second,
+			max:    time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := nextPauseActionAfter(scheduledCluster(nil, false, tc.status))
+			if got < tc.min || got > tc.max {
+				t.Errorf("got %v, want between %v and %v", got, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func sameTime(got, want *time.Time) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Equal(*want)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,85 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	atlas20241113 "go.mongodb.org/atlas-sdk/v20241113001/admin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+const (
+	// upgradeAnnotation is set by the FlexCluster the cluster takes over, its value is the name of the FlexCluster.
+	upgradeAnnotation = "mongodb.com/upgrade-from-flex-cluster"
+	// upgradeCondition reports the progress of the upgrade from a flex cluster.
+	upgradeCondition = "FlexClusterUpgraded"
+)
+
+// upgradeFlexCluster upgrades the flex cluster of the name of the entry to a dedicated cluster with the provider
+// and region of the first region config through the flex cluster upgrade API.
+// The rest of the entry, including the instance size, is applied by an update once the upgrade finished.
+func upgradeFlexCluster(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured, groupID string, entry *atlas20231115.AdvancedClusterDescription) (*atlas20231115.AdvancedClusterDescription, error) {
+	specs := entry.GetReplicationSpecs()
+	if len(specs) == 0 || len(specs[0].GetRegionConfigs()) == 0 {
+		return nil, fmt.Errorf("spec.%s.entry has no region config to upgrade the flex cluster to", version)
+	}
+	region := specs[0].GetRegionConfigs()[0]
+
+	params := &atlas20241113.UpgradeFlexClusterApiParams{
+		GroupId: groupID,
+		FlexClusterDescription20241113: &atlas20241113.FlexClusterDescription20241113{
+			Name: entry.Name,
+			ProviderSettings: atlas20241113.FlexProviderSettings20241113{
+				ProviderName: region.ProviderName,
+				RegionName:   region.RegionName,
+			},
+		},
+	}
+	response, _, err := cs.SdkClient20241113001.FlexClustersApi.UpgradeFlexClusterWithParams(ctx, params).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade flex cluster %s: %w", entry.GetName(), err)
+	}
+
+	setUpgradeCondition(u, metav1.ConditionFalse, "Upgrading", fmt.Sprintf("Upgrading flex cluster %s.", u.GetAnnotations()[upgradeAnnotation]))
+	return &atlas20231115.AdvancedClusterDescription{
+		GroupId:   &groupID,
+		Name:      entry.Name,
+		StateName: response.StateName,
+	}, nil
+}
+
+// upgradePending returns why a cluster upgraded from a flex cluster waits for its entry to be applied.
+func upgradePending(u *unstructured.Unstructured) string {
+	flexCluster, ok := u.GetAnnotations()[upgradeAnnotation]
+	if !ok || meta.IsStatusConditionTrue(status.GetStatus(u).Status.Conditions, upgradeCondition) {
+		return ""
+	}
+	return fmt.Sprintf("Applying spec.%s.entry to the cluster upgraded from flex cluster %s", version, flexCluster)
+}
+
+// completeUpgrade records that the entry was applied to the cluster upgraded from a flex cluster.
+func completeUpgrade(u *unstructured.Unstructured) {
+	if upgradePending(u) == "" {
+		return
+	}
+	setUpgradeCondition(u, metav1.ConditionTrue, "Upgraded", fmt.Sprintf("Upgraded flex cluster %s.", u.GetAnnotations()[upgradeAnnotation]))
+}
+
+func setUpgradeCondition(u *unstructured.Unstructured, conditionStatus metav1.ConditionStatus, reason, message string) {
+	conditions := status.GetStatus(u).Status.Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               upgradeCondition,
+		Status:             conditionStatus,
+		ObservedGeneration: u.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	internalunstructured.SetNestedFieldSlice(u.Object, conditions, "status", "conditions")
+}
@@ -0,0 +1,179 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+type Reconciler = generic.Reconciler[atlas20231115.GetCustomDatabaseRoleApiParams, atlas20231115.UserCustomDBRole]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "custom database role",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetCustomDatabaseRoleApiParams, error) {
+			var (
+				id  atlas20231115.GetCustomDatabaseRoleApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.RoleName, err = generic.Annotation(annotations, "mongodb.com/external-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetCustomDatabaseRoleApiParams {
+			status := generic.Status[atlas20231115.UserCustomDBRole](u, version)
+			return atlas20231115.GetCustomDatabaseRoleApiParams{
+				GroupId:  ref.RecordedGroupID(u),
+				RoleName: status.GetRoleName(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.UserCustomDBRole, error) {
+			params := generic.Params[atlas20231115.CreateCustomDatabaseRoleApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.UserCustomDBRole = generic.Entry[atlas20231115.UserCustomDBRole](u, version)
+
+			if err := waitForInheritedRoles(ctx, c, u, params.UserCustomDBRole.GetInheritedRoles()); err != nil {
+				return nil, err
+			}
+
+			response, _, err := cs.SdkClient20231115008.CustomDatabaseRolesApi.CreateCustomDatabaseRoleWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetCustomDatabaseRoleApiParams) (*atlas20231115.UserCustomDBRole, error) {
+			response, _, err := cs.SdkClient20231115008.CustomDatabaseRolesApi.GetCustomDatabaseRoleWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetCustomDatabaseRoleApiParams, u *unstructured.Unstructured) (*atlas20231115.UserCustomDBRole, error) {
+			params := &atlas20231115.UpdateCustomDatabaseRoleApiParams{
+				GroupId:            id.GroupId,
+				RoleName:           id.RoleName,
+				UpdateCustomDBRole: generic.Entry[atlas20231115.UpdateCustomDBRole](u, version),
+			}
+
+			if err := waitForInheritedRoles(ctx, c, u, params.UpdateCustomDBRole.GetInheritedRoles()); err != nil {
+				return nil, err
+			}
+
+			response, _, err := cs.SdkClient20231115008.CustomDatabaseRolesApi.UpdateCustomDatabaseRoleWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetCustomDatabaseRoleApiParams) error {
+			if err := checkNotInherited(ctx, cs, id); err != nil {
+				return err
+			}
+
+			params := &atlas20231115.DeleteCustomDatabaseRoleApiParams{
+				GroupId:  id.GroupId,
+				RoleName: id.RoleName,
+			}
+			_, err := cs.SdkClient20231115008.CustomDatabaseRolesApi.DeleteCustomDatabaseRoleWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "ATLAS_CUSTOM_ROLE_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+	})
+}
+
+// waitForInheritedRoles returns an error while custom roles inherited by u, which are managed by
+// other CustomDatabaseRole objects in the same namespace, have not been created in Atlas yet.
+func waitForInheritedRoles(ctx context.Context, c client.Client, u *unstructured.Unstructured, inherited []atlas20231115.DatabaseInheritedRole) error {
+	if len(inherited) == 0 {
+		return nil
+	}
+
+	roles, err := ref.List(ctx, c, u, "CustomDatabaseRole")
+	if err != nil {
+		return err
+	}
+
+	managed := make(map[string]*unstructured.Unstructured, len(roles))
+	for i := range roles {
+		roleName := generic.Entry[atlas20231115.UserCustomDBRole](&roles[i], version).GetRoleName()
+		managed[roleName] = &roles[i]
+	}
+
+	self := generic.Entry[atlas20231115.UserCustomDBRole](u, version).GetRoleName()
+	if cycle := inheritanceCycle(self, inherited, managed); cycle != nil {
+		return fmt.Errorf("custom role %s must not inherit from itself: %s", self, strings.Join(cycle, " -> "))
+	}
+	for _, role := range inherited {
+		other, ok := managed[role.Role]
+		if ok && !ref.IsReady(other) {
+			return fmt.Errorf("waiting for inherited custom role %s to be created", role.Role)
+		}
+	}
+
+	return nil
+}
+
+// inheritanceCycle returns the chain of roles through which self inherits from itself, nil if there is none.
+// Roles managed by other CustomDatabaseRole objects are followed by their spec, as they might not exist in Atlas yet.
+func inheritanceCycle(self string, inherited []atlas20231115.DatabaseInheritedRole, managed map[string]*unstructured.Unstructured) []string {
+	visited := map[string]bool{}
+	var walk func(path []string, inherited []atlas20231115.DatabaseInheritedRole) []string
+	walk = func(path []string, inherited []atlas20231115.DatabaseInheritedRole) []string {
+		for _, role := range inherited {
+			chain := append(slices.Clip(path), role.Role)
+			if role.Role == self {
+				return chain
+			}
+			other, ok := managed[role.Role]
+			if !ok || visited[role.Role] {
+				continue
+			}
+			visited[role.Role] = true
+			if cycle := walk(chain, generic.Entry[atlas20231115.UserCustomDBRole](other, version).GetInheritedRoles()); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk([]string{self}, inherited)
+}
+
+// checkNotInherited returns an error while other custom roles in Atlas still inherit from the given role,
+// as Atlas rejects its deletion until they are updated or deleted.
+func checkNotInherited(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetCustomDatabaseRoleApiParams) error {
+	roles, _, err := cs.SdkClient20231115008.CustomDatabaseRolesApi.ListCustomDatabaseRoles(ctx, id.GroupId).Execute()
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		for _, inherited := range role.GetInheritedRoles() {
+			if inherited.Role == id.RoleName {
+				return fmt.Errorf("custom role %s is still inherited by %s", id.RoleName, role.RoleName)
+			}
+		}
+	}
+
+	return nil
+}
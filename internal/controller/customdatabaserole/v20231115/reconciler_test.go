@@ -0,0 +1,71 @@
+package v20231115
+
+import (
+	"slices"
+	"testing"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func inherits(roles ...string) []atlas20231115.DatabaseInheritedRole {
+	var inherited []atlas20231115.DatabaseInheritedRole
+	for _, role := range roles {
+		inherited = append(inherited, atlas20231115.DatabaseInheritedRole{Db: "admin", Role: role})
+	}
+	return inherited
+}
+
+func customRole(name string, inherited ...string) *unstructured.Unstructured {
+	var roles []any
+	for _, role := range inherited {
+		roles = append(roles, map[string]any{"db": "admin", "role": role})
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{version: map[string]any{"entry": map[string]any{"roleName": name, "inheritedRoles": roles}}},
+	}}
+}
+
+func TestInheritanceCycle(t *testing.T) {
+	managed := map[string]*unstructured.Unstructured{
+		"b": customRole("b", "c"),
+		"c": customRole("c", "a"),
+		"d": customRole("d", "e"),
+		"e": customRole("e", "d"),
+		"f": customRole("f", "read"),
+	}
+
+	for _, tc := range []struct {
+		name      string
+		inherited []atlas20231115.DatabaseInheritedRole
+		want      []string
+	}{
+		{
+			name: "no inherited roles",
+		},
+		{
+			name:      "built-in and unmanaged roles",
+			inherited: inherits("read", "f", "unmanaged"),
+		},
+		{
+			name:      "itself",
+			inherited: inherits("a"),
+			want:      []string{"a", "a"},
+		},
+		{
+			name:      "through other roles",
+			inherited: inherits("f", "b"),
+			want:      []string{"a", "b", "c", "a"},
+		},
+		{
+			name:      "cycle not including the role",
+			inherited: inherits("d"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := inheritanceCycle("a", tc.inherited, managed); !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const (
+	version = "v20231115"
+
+	// passwordKey is the default key of the password in the referenced Secret.
+	passwordKey = "password"
+
+	// defaultDatabaseName is the authentication database of users imported without mongodb.com/external-database-name.
+	defaultDatabaseName = "admin"
+)
+
+type Reconciler = generic.Reconciler[atlas20231115.GetDatabaseUserApiParams, atlas20231115.CloudDatabaseUser]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "database user",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetDatabaseUserApiParams, error) {
+			var (
+				id  atlas20231115.GetDatabaseUserApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.DatabaseName = annotations["mongodb.com/external-database-name"]; id.DatabaseName == "" {
+				id.DatabaseName = defaultDatabaseName
+			}
+			if id.Username, err = generic.Annotation(annotations, "mongodb.com/external-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetDatabaseUserApiParams {
+			status := generic.Status[atlas20231115.CloudDatabaseUser](u, version)
+			return atlas20231115.GetDatabaseUserApiParams{
+				GroupId:      status.GetGroupId(),
+				DatabaseName: status.GetDatabaseName(),
+				Username:     status.GetUsername(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.CloudDatabaseUser, error) {
+			params := generic.Params[atlas20231115.CreateDatabaseUserApiParams](u, version)
+			params.CloudDatabaseUser = generic.Entry[atlas20231115.CloudDatabaseUser](u, version)
+
+			hash, err := setPassword(ctx, c, u, params.CloudDatabaseUser)
+			if err != nil {
+				return nil, err
+			}
+
+			response, _, err := cs.SdkClient20231115008.DatabaseUsersApi.CreateDatabaseUserWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetDatabaseUserApiParams) (*atlas20231115.CloudDatabaseUser, error) {
+			response, _, err := cs.SdkClient20231115008.DatabaseUsersApi.GetDatabaseUserWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetDatabaseUserApiParams, u *unstructured.Unstructured) (*atlas20231115.CloudDatabaseUser, error) {
+			params := &atlas20231115.UpdateDatabaseUserApiParams{
+				GroupId:           id.GroupId,
+				DatabaseName:      id.DatabaseName,
+				Username:          id.Username,
+				CloudDatabaseUser: generic.Entry[atlas20231115.CloudDatabaseUser](u, version),
+			}
+
+			hash, err := setPassword(ctx, c, u, params.CloudDatabaseUser)
+			if err != nil {
+				return nil, err
+			}
+
+			response, _, err := cs.SdkClient20231115008.DatabaseUsersApi.UpdateDatabaseUserWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetDatabaseUserApiParams) error {
+			params := &atlas20231115.DeleteDatabaseUserApiParams{
+				GroupId:      id.GroupId,
+				DatabaseName: id.DatabaseName,
+				Username:     id.Username,
+			}
+			_, _, err := cs.SdkClient20231115008.DatabaseUsersApi.DeleteDatabaseUserWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "USERNAME_NOT_FOUND")
+		},
+
+		// a changed password in the referenced Secret is rolled out as an update.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			hash, err := setPassword(ctx, c, u, &atlas20231115.CloudDatabaseUser{})
+			if err != nil {
+				return false, err
+			}
+			return hash != secret.AppliedHash(u), nil
+		},
+	})
+}
+
+// setPassword sets the password of the given user from the Secret referenced in spec.v20231115.passwordSecretRef
+// and returns its hash. Users without a Secret reference keep the password given in the entry, if any.
+func setPassword(ctx context.Context, c client.Client, u *unstructured.Unstructured, user *atlas20231115.CloudDatabaseUser) (string, error) {
+	ref := secret.GetRef(u, "spec", version, "passwordSecretRef")
+	if ref == nil {
+		return "", nil
+	}
+
+	password, err := secret.Value(ctx, c, u.GetNamespace(), ref, passwordKey)
+	if err != nil {
+		return "", err
+	}
+
+	user.SetPassword(password)
+	return secret.Hash(password), nil
+}
@@ -0,0 +1,98 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages a federated database instance of a project, i.e. one querying the online archives of a cluster.
+type Reconciler = generic.Reconciler[atlas20231115.GetFederatedDatabaseApiParams, atlas20231115.DataLakeTenant]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "data federation",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetFederatedDatabaseApiParams, error) {
+			var (
+				id  atlas20231115.GetFederatedDatabaseApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.TenantName, err = generic.Annotation(annotations, "mongodb.com/external-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetFederatedDatabaseApiParams {
+			status := generic.Status[atlas20231115.DataLakeTenant](u, version)
+			return atlas20231115.GetFederatedDatabaseApiParams{
+				GroupId:    status.GetGroupId(),
+				TenantName: status.GetName(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.DataLakeTenant, error) {
+			params := generic.Params[atlas20231115.CreateFederatedDatabaseApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.DataLakeTenant = generic.Entry[atlas20231115.DataLakeTenant](u, version)
+			response, _, err := cs.SdkClient20231115008.DataFederationApi.CreateFederatedDatabaseWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetFederatedDatabaseApiParams) (*atlas20231115.DataLakeTenant, error) {
+			response, _, err := cs.SdkClient20231115008.DataFederationApi.GetFederatedDatabaseWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetFederatedDatabaseApiParams, u *unstructured.Unstructured) (*atlas20231115.DataLakeTenant, error) {
+			params := &atlas20231115.UpdateFederatedDatabaseApiParams{
+				GroupId:        id.GroupId,
+				TenantName:     id.TenantName,
+				DataLakeTenant: generic.Entry[atlas20231115.DataLakeTenant](u, version),
+				// the role validation is skipped on update if it was skipped on create.
+				SkipRoleValidation: generic.Params[atlas20231115.CreateFederatedDatabaseApiParams](u, version).SkipRoleValidation,
+			}
+			response, _, err := cs.SdkClient20231115008.DataFederationApi.UpdateFederatedDatabaseWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetFederatedDatabaseApiParams) error {
+			params := &atlas20231115.DeleteFederatedDatabaseApiParams{
+				GroupId:    id.GroupId,
+				TenantName: id.TenantName,
+			}
+			_, _, err := cs.SdkClient20231115008.DataFederationApi.DeleteFederatedDatabaseWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "DATA_LAKE_TENANT_NOT_FOUND_FOR_NAME") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.DataLakeTenant) any {
+			return atlas20231115.DataLakeTenant{
+				CloudProviderConfig: response.CloudProviderConfig,
+				DataProcessRegion:   response.DataProcessRegion,
+				Name:                response.Name,
+				Storage:             response.Storage,
+			}
+		},
+	})
+}
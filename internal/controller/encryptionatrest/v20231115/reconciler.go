@@ -0,0 +1,213 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const version = "v20231115"
+
+// Reconciler manages the customer key management settings for encryption at rest of a project.
+//
+// Credentials are read from the Secret referenced in spec.v20231115.credentialsSecretRef, using the keys
+// accessKeyID and secretAccessKey for AWS KMS, secret for Azure Key Vault and serviceAccountKey for GCP KMS.
+// Atlas validates the key when the settings are applied. The resource stays in the Creating or Updating state
+// until Atlas reports the key as valid, invalid keys are reported as errors. Deleting the resource disables
+// encryption at rest using customer key management for all providers.
+type Reconciler = generic.Reconciler[atlas20231115.GetEncryptionAtRestApiParams, atlas20231115.EncryptionAtRest]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "encryption at rest",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetEncryptionAtRestApiParams, error) {
+			groupID, err := generic.Annotation(annotations, "mongodb.com/external-group-id")
+			return atlas20231115.GetEncryptionAtRestApiParams{GroupId: groupID}, err
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetEncryptionAtRestApiParams {
+			return atlas20231115.GetEncryptionAtRestApiParams{GroupId: ref.RecordedGroupID(u)}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.EncryptionAtRest, error) {
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			return update(ctx, cs, c, groupID, u)
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetEncryptionAtRestApiParams) (*atlas20231115.EncryptionAtRest, error) {
+			response, _, err := cs.SdkClient20231115008.EncryptionAtRestUsingCustomerKeyManagementApi.GetEncryptionAtRestWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetEncryptionAtRestApiParams, u *unstructured.Unstructured) (*atlas20231115.EncryptionAtRest, error) {
+			return update(ctx, cs, c, id.GroupId, u)
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetEncryptionAtRestApiParams) error {
+			params := &atlas20231115.UpdateEncryptionAtRestApiParams{
+				GroupId: id.GroupId,
+				EncryptionAtRest: &atlas20231115.EncryptionAtRest{
+					AwsKms:         &atlas20231115.AWSKMSConfiguration{Enabled: atlas20231115.PtrBool(false)},
+					AzureKeyVault:  &atlas20231115.AzureKeyVault{Enabled: atlas20231115.PtrBool(false)},
+					GoogleCloudKms: &atlas20231115.GoogleCloudKMS{Enabled: atlas20231115.PtrBool(false)},
+				},
+			}
+			_, _, err := cs.SdkClient20231115008.EncryptionAtRestUsingCustomerKeyManagementApi.UpdateEncryptionAtRestWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// rotated credentials are applied although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			_, hash, err := credentials(ctx, c, u)
+			if err != nil {
+				return false, err
+			}
+			return hash != secret.AppliedHash(u), nil
+		},
+
+		ImportEntry: func(response *atlas20231115.EncryptionAtRest) any {
+			entry := atlas20231115.EncryptionAtRest{}
+			if aws := response.AwsKms; aws != nil {
+				entry.AwsKms = &atlas20231115.AWSKMSConfiguration{
+					AccessKeyID:         aws.AccessKeyID,
+					CustomerMasterKeyID: aws.CustomerMasterKeyID,
+					Enabled:             aws.Enabled,
+					Region:              aws.Region,
+					RoleId:              aws.RoleId,
+				}
+			}
+			if azure := response.AzureKeyVault; azure != nil {
+				entry.AzureKeyVault = &atlas20231115.AzureKeyVault{
+					AzureEnvironment:  azure.AzureEnvironment,
+					ClientID:          azure.ClientID,
+					Enabled:           azure.Enabled,
+					KeyIdentifier:     azure.KeyIdentifier,
+					KeyVaultName:      azure.KeyVaultName,
+					ResourceGroupName: azure.ResourceGroupName,
+					SubscriptionID:    azure.SubscriptionID,
+					TenantID:          azure.TenantID,
+				}
+			}
+			if gcp := response.GoogleCloudKms; gcp != nil {
+				entry.GoogleCloudKms = &atlas20231115.GoogleCloudKMS{
+					Enabled:              gcp.Enabled,
+					KeyVersionResourceID: gcp.KeyVersionResourceID,
+				}
+			}
+			return entry
+		},
+
+		StateName:    keyState,
+		BusyStates:   []string{"VALIDATING"},
+		FailedStates: []string{"INVALID"},
+		// the settings remain in Atlas as long as the project exists.
+		SyncDelete: true,
+	})
+}
+
+func update(ctx context.Context, cs *atlas.ClientSet, c client.Reader, groupID string, u *unstructured.Unstructured) (*atlas20231115.EncryptionAtRest, error) {
+	entry, hash, err := credentials(ctx, c, u)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &atlas20231115.UpdateEncryptionAtRestApiParams{
+		GroupId:          groupID,
+		EncryptionAtRest: entry,
+	}
+	response, _, err := cs.SdkClient20231115008.EncryptionAtRestUsingCustomerKeyManagementApi.UpdateEncryptionAtRestWithParams(ctx, params).Execute()
+	if err != nil {
+		return nil, err
+	}
+	secret.SetAppliedHash(u, hash)
+	return response, nil
+}
+
+// credentials returns the entry with the credentials of the configured providers set from the Secret referenced in
+// spec.v20231115.credentialsSecretRef and the hash of the applied credentials.
+func credentials(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (*atlas20231115.EncryptionAtRest, string, error) {
+	entry := generic.Entry[atlas20231115.EncryptionAtRest](u, version)
+
+	ref := secret.GetRef(u, "spec", version, "credentialsSecretRef")
+	if ref == nil {
+		return entry, "", nil
+	}
+	data, err := secret.Data(ctx, c, u.GetNamespace(), ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var values []string
+	value := func(key string) *string {
+		v, ok := data[key]
+		if !ok {
+			return nil
+		}
+		values = append(values, key, string(v))
+		return atlas20231115.PtrString(string(v))
+	}
+
+	if entry.AwsKms != nil {
+		if v := value("accessKeyID"); v != nil {
+			entry.AwsKms.AccessKeyID = v
+		}
+		if v := value("secretAccessKey"); v != nil {
+			entry.AwsKms.SecretAccessKey = v
+		}
+	}
+	if entry.AzureKeyVault != nil {
+		if v := value("secret"); v != nil {
+			entry.AzureKeyVault.Secret = v
+		}
+	}
+	if entry.GoogleCloudKms != nil {
+		if v := value("serviceAccountKey"); v != nil {
+			entry.GoogleCloudKms.ServiceAccountKey = v
+		}
+	}
+	return entry, secret.Hash(values...), nil
+}
+
+// keyState summarizes the validation of the keys of all enabled providers. It returns VALIDATING as long as
+// Atlas did not report the validity of an enabled key yet and DISABLED if no provider is enabled.
+func keyState(config *atlas20231115.EncryptionAtRest) string {
+	var valid []*bool
+	if aws := config.AwsKms; aws != nil && aws.GetEnabled() {
+		valid = append(valid, aws.Valid)
+	}
+	if azure := config.AzureKeyVault; azure != nil && azure.GetEnabled() {
+		valid = append(valid, azure.Valid)
+	}
+	if gcp := config.GoogleCloudKms; gcp != nil && gcp.GetEnabled() {
+		valid = append(valid, gcp.Valid)
+	}
+
+	if len(valid) == 0 {
+		return "DISABLED"
+	}
+	for _, v := range valid {
+		switch {
+		case v == nil:
+			return "VALIDATING"
+		case !*v:
+			return "INVALID"
+		}
+	}
+	return "VALID"
+}
@@ -0,0 +1,122 @@
+package v20241113
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	atlas20241113 "go.mongodb.org/atlas-sdk/v20241113001/admin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const (
+	// upgradeAnnotation marks a Cluster upgrading the flex cluster of the FlexCluster named in its value.
+	upgradeAnnotation = "mongodb.com/upgrade-from-flex-cluster"
+	clusterVersion    = "v20231115"
+)
+
+// get returns the flex cluster, or the dedicated cluster it was upgraded to.
+func get(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetFlexClusterApiParams) (*atlas20241113.FlexClusterDescription20241113, error) {
+	response, _, err := cs.SdkClient20241113001.FlexClustersApi.GetFlexClusterWithParams(ctx, &id).Execute()
+	if !atlas20241113.IsErrorCode(err, "CANNOT_USE_NON_FLEX_CLUSTER_IN_FLEX_API") {
+		return response, err
+	}
+
+	cluster, _, err := cs.SdkClient20241113001.ClustersApi.GetCluster(ctx, id.GroupId, id.Name).Execute()
+	if err != nil {
+		return nil, err
+	}
+	response = &atlas20241113.FlexClusterDescription20241113{
+		ClusterType:                  cluster.ClusterType,
+		CreateDate:                   cluster.CreateDate,
+		GroupId:                      cluster.GroupId,
+		Id:                           cluster.Id,
+		MongoDBVersion:               cluster.MongoDBVersion,
+		Name:                         cluster.Name,
+		StateName:                    cluster.StateName,
+		Tags:                         cluster.Tags,
+		TerminationProtectionEnabled: cluster.TerminationProtectionEnabled,
+		VersionReleaseSystem:         cluster.VersionReleaseSystem,
+	}
+	if cluster.ConnectionStrings != nil {
+		response.ConnectionStrings = &atlas20241113.FlexConnectionStrings20241113{
+			Standard:    cluster.ConnectionStrings.Standard,
+			StandardSrv: cluster.ConnectionStrings.StandardSrv,
+		}
+	}
+	return response, nil
+}
+
+// errHandedOver is returned when deleting a flex cluster handed over to a Cluster, IsNotFound matches it.
+var errHandedOver = errors.New("flex cluster is handed over to a cluster")
+
+// handedOver reports whether a Cluster upgrading the flex cluster with the given ID exists.
+// Such flex clusters are left to the Cluster, even while the upgrade has not started yet.
+func handedOver(ctx context.Context, c client.Reader, id atlas20241113.GetFlexClusterApiParams) (bool, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.GroupVersion, Kind: "ClusterList"})
+	if err := c.List(ctx, list); err != nil {
+		return false, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	for _, cluster := range list.Items {
+		if _, ok := cluster.GetAnnotations()[upgradeAnnotation]; !ok {
+			continue
+		}
+		groupID, _, _ := unstructured.NestedString(cluster.Object, "spec", clusterVersion, "parameters", "groupId")
+		name, _, _ := unstructured.NestedString(cluster.Object, "spec", clusterVersion, "entry", "name")
+		if groupID == id.GroupId && name == id.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// handOver creates the Cluster u is upgraded to in spec.<version>.upgradeTo unless it exists already.
+// The Cluster upgrades the flex cluster in Atlas and applies its entry once the upgrade finished.
+// It is not owned by u, so it outlives the FlexCluster.
+func handOver(ctx context.Context, c client.Client, u *unstructured.Unstructured, id atlas20241113.GetFlexClusterApiParams) error {
+	name, _, _ := unstructured.NestedString(u.Object, "spec", version, "upgradeTo", "name")
+	if name == "" {
+		name = u.GetName()
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(u.GroupVersionKind().GroupVersion().WithKind("Cluster"))
+	err := c.Get(ctx, client.ObjectKey{Namespace: u.GetNamespace(), Name: name}, cluster)
+	switch {
+	case err == nil:
+		if cluster.GetAnnotations()[upgradeAnnotation] != u.GetName() {
+			return fmt.Errorf("cluster %s exists already and does not upgrade flex cluster %s", name, u.GetName())
+		}
+		return nil
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("failed to get cluster %s: %w", name, err)
+	}
+
+	entry, _, err := unstructured.NestedMap(u.Object, "spec", version, "upgradeTo", "entry")
+	if err != nil {
+		return fmt.Errorf("invalid spec.%s.upgradeTo.entry: %w", version, err)
+	}
+	// the cluster keeps its name in Atlas, so connection strings and references to it stay valid.
+	entry["name"] = id.Name
+
+	cluster.SetNamespace(u.GetNamespace())
+	cluster.SetName(name)
+	cluster.SetAnnotations(map[string]string{upgradeAnnotation: u.GetName()})
+	cluster.Object["spec"] = map[string]any{
+		clusterVersion: map[string]any{
+			"parameters": map[string]any{"groupId": id.GroupId},
+			"entry":      entry,
+		},
+	}
+	if err := c.Create(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to create cluster %s: %w", name, err)
+	}
+	return nil
+}
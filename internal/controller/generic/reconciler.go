@@ -0,0 +1,471 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	ctrlstate "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/result"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+// Reconciler is a StateReconciler for Atlas resources with plain create/get/update/delete semantics.
+//
+// ID is the type identifying the resource in Atlas, i.e. a project ID or the SDK get parameters.
+// S is the Atlas response type which is stored in status under the Version key.
+type Reconciler[ID, S any] struct {
+	ctrlstate.StateReconciler
+	Client client.Client
+
+	// Kind is the human-readable resource name used in state messages, i.e. "cluster".
+	Kind string
+	// Version is the spec and status key, i.e. "v20231115".
+	Version string
+
+	// ImportID extracts the Atlas ID from the mongodb.com/external-* annotations.
+	ImportID func(annotations map[string]string) (ID, error)
+	// ID extracts the Atlas ID from an already created or imported resource.
+	ID func(u *unstructured.Unstructured) ID
+
+	Create func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*S, error)
+	Get    func(ctx context.Context, cs *atlas.ClientSet, id ID) (*S, error)
+	Update func(ctx context.Context, cs *atlas.ClientSet, id ID, u *unstructured.Unstructured) (*S, error)
+	Delete func(ctx context.Context, cs *atlas.ClientSet, id ID) error
+
+	// IsNotFound reports whether the given error signals a missing Atlas resource.
+	IsNotFound func(error) bool
+
+	// Changed optionally reports changes outside of the object requiring an update although the generation
+	// did not change, i.e. rotated credentials in a referenced Secret.
+	Changed func(ctx context.Context, u *unstructured.Unstructured) (bool, error)
+
+	// ImportEntry optionally converts the Atlas response into the spec entry on import.
+	// If nil, the response is stored as-is.
+	ImportEntry func(*S) any
+
+	// StateName optionally returns the Atlas lifecycle state of the resource.
+	// Resources reporting one of BusyStates are polled in the Creating, Updating and Deleting states.
+	// If BusyStates is empty, the resource is considered to be settled synchronously.
+	StateName  func(*S) string
+	BusyStates []string
+	// FailedStates are the values of StateName in which an async resource settled without succeeding.
+	// They are reported as errors when polling in the Creating and Updating states.
+	FailedStates []string
+	// ErrorMessage optionally returns the error reported by Atlas for a failed resource.
+	ErrorMessage func(*S) string
+
+	// SyncDelete completes deletions of async resources without polling,
+	// i.e. for jobs which Atlas keeps after they finished.
+	SyncDelete bool
+
+	// Pending optionally returns why an existing resource waits before it settles,
+	// i.e. a role which has to be authorized once the user set up their side.
+	// Pending resources stay in the Creating or Updating state with the returned message
+	// and are updated as soon as their generation changes or Changed reports changes.
+	Pending func(u *unstructured.Unstructured, response *S) string
+
+	// RequeueAfter optionally returns when a resource is reconciled again although nothing changed,
+	// i.e. for actions scheduled at a given time. Zero means it is only reconciled again once its generation changes.
+	RequeueAfter func(u *unstructured.Unstructured) time.Duration
+
+	// Sections are optional parts of the resource under spec.<version> managed through separate Atlas endpoints.
+	Sections []Section[ID]
+}
+
+// Section is an optional part of a resource, i.e. the settings of a project, which is applied independently
+// of the resource itself and of the other sections once the resource exists in Atlas.
+// Its outcome is reported in its own condition, failures don't affect the state of the resource
+// and are retried after SectionRetryInterval.
+type Section[ID any] struct {
+	// Field is the key of the section under spec.<version>, i.e. "settings".
+	Field string
+	// Condition is the type of the condition reporting the section, i.e. "SettingsReady".
+	Condition string
+	// Apply is called on every reconciliation of a resource with the section set.
+	// It is expected to only write the values differing from Atlas and to return ErrSectionPending
+	// if the section can not be applied yet.
+	Apply func(ctx context.Context, cs *atlas.ClientSet, id ID, u *unstructured.Unstructured) error
+}
+
+// ErrSectionPending is returned, optionally wrapped, by Section.Apply while a section can not be applied yet,
+// i.e. as Atlas rejects changes of a busy resource. The section is reported as pending instead of failed
+// and applied again after SectionRetryInterval.
+var ErrSectionPending = errors.New("section pending")
+
+const (
+	// SectionRetryInterval is the delay after which a resource is reconciled again if one of its sections failed
+	// or is pending.
+	SectionRetryInterval = time.Minute
+	// ResyncInterval is the delay after which resources using Resync are reconciled again.
+	ResyncInterval = 10 * time.Minute
+)
+
+// Resync is a RequeueAfter for resources comparing their spec against Atlas through Changed or Sections.
+// It reconciles them again after ResyncInterval, so changes made in Atlas are set back although the generation
+// did not change.
+func Resync(*unstructured.Unstructured) time.Duration {
+	return ResyncInterval
+}
+
+// NewReconciler returns a fully wired StateReconciler for the given resource definition.
+func NewReconciler[ID, S any](c client.Client, r Reconciler[ID, S]) *Reconciler[ID, S] {
+	r.Client = c
+	return &r
+}
+
+func (r *Reconciler[ID, S]) HandleImportRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	atlasClients := atlas.FromContext(ctx)
+
+	id, err := r.ImportID(u.GetAnnotations())
+	if err != nil {
+		return result.Error(state.StateImportRequested, err)
+	}
+
+	response, err := r.Get(ctx, atlasClients, id)
+	if err != nil {
+		return result.Error(state.StateImportRequested, fmt.Errorf("failed to get %s: %w", r.Kind, err))
+	}
+
+	var entry any = response
+	if r.ImportEntry != nil {
+		entry = r.ImportEntry(response)
+	}
+	internalunstructured.SetNestedField[any](u.Object, entry, "spec", r.Version, "entry")
+
+	err = r.Client.Patch(ctx, u, client.RawPatch(types.MergePatchType, json.MustMarshal(u.Object)))
+	if err != nil {
+		return result.Error(state.StateImportRequested, fmt.Errorf("failed to patch %s: %w", r.Kind, err))
+	}
+
+	r.setStatus(u, response)
+
+	return result.NextState(state.StateImported, fmt.Sprintf("Imported %s", r.Kind))
+}
+
+func (r *Reconciler[ID, S]) HandleInitial(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	atlasClients := atlas.FromContext(ctx)
+
+	response, err := r.Create(ctx, atlasClients, u)
+	if err != nil {
+		return result.Error(state.StateInitial, fmt.Errorf("failed to create %s: %w", r.Kind, err))
+	}
+
+	r.setStatus(u, response)
+
+	if r.isAsync() {
+		return result.NextState(state.StateCreating, fmt.Sprintf("Creating %s", r.Kind))
+	}
+
+	if msg := r.pending(u, response); msg != "" {
+		return result.NextState(state.StateCreating, msg)
+	}
+
+	retrySections := r.reconcileSections(ctx, u, r.ID(u))
+	res, err := result.NextState(state.StateCreated, fmt.Sprintf("Created %s", r.Kind))
+	return r.requeue(u, res, retrySections), err
+}
+
+func (r *Reconciler[ID, S]) HandleImported(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	return r.HandleIdle(ctx, u, state.StateImported)
+}
+
+func (r *Reconciler[ID, S]) HandleCreated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	return r.HandleIdle(ctx, u, state.StateCreated)
+}
+
+func (r *Reconciler[ID, S]) HandleUpdated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	return r.HandleIdle(ctx, u, state.StateUpdated)
+}
+
+func (r *Reconciler[ID, S]) HandleIdle(ctx context.Context, u *unstructured.Unstructured, currentState state.ResourceState) (res ctrlstate.Result, err error) {
+	atlasClients := atlas.FromContext(ctx)
+	id := r.ID(u)
+	defer func() {
+		// sections are applied whatever the outcome for the resource itself is.
+		retrySections := r.reconcileSections(ctx, u, id)
+		if err == nil {
+			res = r.requeue(u, res, retrySections)
+		}
+	}()
+
+	response, err := r.Get(ctx, atlasClients, id)
+	if err != nil {
+		return result.Error(currentState, fmt.Errorf("failed to get %s: %w", r.Kind, err))
+	}
+	r.setStatus(u, response)
+
+	if r.isBusy(response) {
+		return result.NextState(state.StateUpdating, fmt.Sprintf("Updating %s", r.Kind))
+	}
+
+	needsUpdate := NeedsUpdate(u)
+	if !needsUpdate && r.Changed != nil {
+		if needsUpdate, err = r.Changed(ctx, u); err != nil {
+			return result.Error(currentState, fmt.Errorf("failed to detect changes of %s: %w", r.Kind, err))
+		}
+	}
+
+	if !needsUpdate {
+		if msg := r.pending(u, response); msg != "" {
+			return result.NextState(state.StateUpdating, msg)
+		}
+		return result.NextState(currentState, fmt.Sprintf("Upserted %s", r.Kind))
+	}
+
+	response, err = r.Update(ctx, atlasClients, id, u)
+	if err != nil {
+		return result.Error(currentState, fmt.Errorf("failed to update %s: %w", r.Kind, err))
+	}
+	r.setStatus(u, response)
+
+	if r.isAsync() {
+		return result.NextState(state.StateUpdating, fmt.Sprintf("Updating %s", r.Kind))
+	}
+
+	if msg := r.pending(u, response); msg != "" {
+		return result.NextState(state.StateUpdating, msg)
+	}
+
+	return result.NextState(state.StateUpdated, fmt.Sprintf("Updated %s", r.Kind))
+}
+
+func (r *Reconciler[ID, S]) HandleUpserting(ctx context.Context, u *unstructured.Unstructured, currentState, finalState state.ResourceState) (ctrlstate.Result, error) {
+	atlasClients := atlas.FromContext(ctx)
+
+	response, err := r.Get(ctx, atlasClients, r.ID(u))
+	if err != nil {
+		return result.Error(currentState, fmt.Errorf("failed to get %s: %w", r.Kind, err))
+	}
+	r.setStatus(u, response)
+
+	if r.isBusy(response) {
+		return result.NextState(currentState, fmt.Sprintf("Upserting %s, Atlas reports %s", r.Kind, r.StateName(response)))
+	}
+
+	if r.isFailed(response) {
+		err := fmt.Errorf("%s failed in state %s", r.Kind, r.StateName(response))
+		if r.ErrorMessage != nil && r.ErrorMessage(response) != "" {
+			err = fmt.Errorf("%w: %s", err, r.ErrorMessage(response))
+		}
+		return result.Error(currentState, err)
+	}
+
+	if msg := r.pending(u, response); msg != "" {
+		// the generation of pending resources is not observed before they settle.
+		needsUpdate := NeedsUpdate(u)
+		if !needsUpdate && r.Changed != nil {
+			if needsUpdate, err = r.Changed(ctx, u); err != nil {
+				return result.Error(currentState, fmt.Errorf("failed to detect changes of %s: %w", r.Kind, err))
+			}
+		}
+		if !needsUpdate {
+			return result.NextState(currentState, msg)
+		}
+		if response, err = r.Update(ctx, atlasClients, r.ID(u), u); err != nil {
+			return result.Error(currentState, fmt.Errorf("failed to update %s: %w", r.Kind, err))
+		}
+		r.setStatus(u, response)
+		if r.isAsync() {
+			return result.NextState(currentState, fmt.Sprintf("Upserting %s", r.Kind))
+		}
+		if msg := r.pending(u, response); msg != "" {
+			return result.NextState(currentState, msg)
+		}
+	}
+
+	retrySections := r.reconcileSections(ctx, u, r.ID(u))
+	res, err := result.NextState(finalState, fmt.Sprintf("Upserted %s", r.Kind))
+	return r.requeue(u, res, retrySections), err
+}
+
+func (r *Reconciler[ID, S]) HandleCreating(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	return r.HandleUpserting(ctx, u, state.StateCreating, state.StateCreated)
+}
+
+func (r *Reconciler[ID, S]) HandleUpdating(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	return r.HandleUpserting(ctx, u, state.StateUpdating, state.StateUpdated)
+}
+
+func (r *Reconciler[ID, S]) HandleDeletionRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	if !r.hasStatus(u) {
+		// the resource never made it to Atlas, nothing to delete.
+		return result.NextState(state.StateDeleted, fmt.Sprintf("Deleted %s", r.Kind))
+	}
+
+	atlasClients := atlas.FromContext(ctx)
+	err := r.Delete(ctx, atlasClients, r.ID(u))
+	switch {
+	case r.IsNotFound(err):
+		return result.NextState(state.StateDeleted, fmt.Sprintf("Deleted %s", r.Kind))
+	case err != nil:
+		return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to delete %s: %w", r.Kind, err))
+	}
+
+	if !r.isAsync() || r.SyncDelete {
+		return result.NextState(state.StateDeleted, fmt.Sprintf("Deleted %s", r.Kind))
+	}
+
+	return result.NextState(state.StateDeleting, fmt.Sprintf("Deleting %s", r.Kind))
+}
+
+func (r *Reconciler[ID, S]) HandleDeleting(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
+	atlasClients := atlas.FromContext(ctx)
+
+	response, err := r.Get(ctx, atlasClients, r.ID(u))
+	switch {
+	case r.IsNotFound(err):
+		return result.NextState(state.StateDeleted, fmt.Sprintf("Deleted %s", r.Kind))
+	case err != nil:
+		return result.Error(state.StateDeleting, fmt.Errorf("failed to get %s: %w", r.Kind, err))
+	}
+	r.setStatus(u, response)
+
+	return result.NextState(state.StateDeleting, fmt.Sprintf("Deleting %s", r.Kind))
+}
+
+func (r *Reconciler[ID, S]) isAsync() bool {
+	return r.StateName != nil && len(r.BusyStates) > 0
+}
+
+func (r *Reconciler[ID, S]) isBusy(response *S) bool {
+	if !r.isAsync() {
+		return false
+	}
+	return slices.Contains(r.BusyStates, r.StateName(response))
+}
+
+func (r *Reconciler[ID, S]) isFailed(response *S) bool {
+	if !r.isAsync() {
+		return false
+	}
+	return slices.Contains(r.FailedStates, r.StateName(response))
+}
+
+func (r *Reconciler[ID, S]) pending(u *unstructured.Unstructured, response *S) string {
+	if r.Pending == nil {
+		return ""
+	}
+	return r.Pending(u, response)
+}
+
+// requeue shortens the delay after which res reconciles u again to the one returned by RequeueAfter
+// and, if a section failed or is pending, to SectionRetryInterval.
+func (r *Reconciler[ID, S]) requeue(u *unstructured.Unstructured, res ctrlstate.Result, retrySections bool) ctrlstate.Result {
+	var after []time.Duration
+	if r.RequeueAfter != nil {
+		after = append(after, r.RequeueAfter(u))
+	}
+	if retrySections {
+		after = append(after, SectionRetryInterval)
+	}
+	for _, d := range after {
+		if d > 0 && (res.RequeueAfter == 0 || d < res.RequeueAfter) {
+			res.RequeueAfter = d
+		}
+	}
+	return res
+}
+
+// reconcileSections applies the sections set in spec, reports each in its condition
+// and returns whether one of them failed or is pending. Conditions of sections removed from spec are dropped.
+func (r *Reconciler[ID, S]) reconcileSections(ctx context.Context, u *unstructured.Unstructured, id ID) bool {
+	if len(r.Sections) == 0 {
+		return false
+	}
+
+	retry := false
+
+	conditions := status.GetStatus(u).Status.Conditions
+	for _, section := range r.Sections {
+		if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", r.Version, section.Field); !found {
+			meta.RemoveStatusCondition(&conditions, section.Condition)
+			continue
+		}
+
+		condition := metav1.Condition{
+			Type:               section.Condition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: u.GetGeneration(),
+			Reason:             ctrlstate.ReadyReasonSettled,
+			Message:            fmt.Sprintf("Applied %s.", section.Field),
+		}
+		switch err := section.Apply(ctx, atlas.FromContext(ctx), id, u); {
+		case errors.Is(err, ErrSectionPending):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = ctrlstate.ReadyReasonPending
+			condition.Message = fmt.Sprintf("Waiting to apply %s: %v.", section.Field, err)
+			retry = true
+		case err != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = ctrlstate.ReadyReasonError
+			condition.Message = fmt.Sprintf("failed to apply %s: %v", section.Field, err)
+			retry = true
+		}
+		meta.SetStatusCondition(&conditions, condition)
+	}
+	internalunstructured.SetNestedFieldSlice(u.Object, conditions, "status", "conditions")
+	return retry
+}
+
+func (r *Reconciler[ID, S]) hasStatus(u *unstructured.Unstructured) bool {
+	v, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status", r.Version)
+	return found && v != nil
+}
+
+func (r *Reconciler[ID, S]) setStatus(u *unstructured.Unstructured, response *S) {
+	SetStatus(u, r.Version, response)
+}
+
+// NeedsUpdate reports whether the current generation has not been applied yet
+// or the last reconciliation failed.
+func NeedsUpdate(u *unstructured.Unstructured) bool {
+	st := status.GetStatus(u)
+	stateCondition := meta.FindStatusCondition(st.Status.Conditions, state.StateCondition)
+	readyCondition := meta.FindStatusCondition(st.Status.Conditions, state.ReadyCondition)
+	if stateCondition == nil || readyCondition == nil {
+		return true
+	}
+	return stateCondition.ObservedGeneration != u.GetGeneration() || readyCondition.Reason == ctrlstate.ReadyReasonError
+}
+
+// Annotation returns the value of the given annotation key or an error if it is missing.
+func Annotation(annotations map[string]string, key string) (string, error) {
+	v, ok := annotations[key]
+	if !ok {
+		return "", errors.New("missing " + key)
+	}
+	return v, nil
+}
+
+func Params[T any](u *unstructured.Unstructured, version string) *T {
+	return json.ConvertNestedField[T](u.Object, "spec", version, "parameters")
+}
+
+func Entry[T any](u *unstructured.Unstructured, version string) *T {
+	return json.ConvertNestedField[T](u.Object, "spec", version, "entry")
+}
+
+func SectionValue[T any](u *unstructured.Unstructured, version, field string) *T {
+	return json.ConvertNestedField[T](u.Object, "spec", version, field)
+}
+
+func Status[T any](u *unstructured.Unstructured, version string) *T {
+	return json.ConvertNestedField[T](u.Object, "status", version)
+}
+
+func SetStatus(u *unstructured.Unstructured, version string, s any) {
+	internalunstructured.SetNestedFieldObject(u.Object, s, "status", version)
+}
@@ -0,0 +1,175 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	ctrlstate "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+)
+
+type testResource struct {
+	Name      string `json:"name"`
+	StateName string `json:"stateName"`
+}
+
+// settledObject returns an object whose generation has been applied.
+func settledObject() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"v1": map[string]any{"entry": map[string]any{"name": "test"}}},
+	}}
+	u.SetGeneration(1)
+	conditions := []metav1.Condition{
+		{Type: state.StateCondition, Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: string(state.StateCreated)},
+		{Type: state.ReadyCondition, Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: ctrlstate.ReadyReasonSettled},
+	}
+	internalunstructured.SetNestedFieldSlice(u.Object, conditions, "status", "conditions")
+	return u
+}
+
+func TestHandleIdleRequeue(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		stateName    string
+		requeueAfter func(*unstructured.Unstructured) time.Duration
+		wantState    state.ResourceState
+		want         time.Duration
+	}{
+		{
+			name:      "settled without hook",
+			stateName: "IDLE",
+			wantState: state.StateCreated,
+		},
+		{
+			name:         "settled with nothing scheduled",
+			stateName:    "IDLE",
+			requeueAfter: func(*unstructured.Unstructured) time.Duration { return 0 },
+			wantState:    state.StateCreated,
+		},
+		{
+			name:         "settled wakes up for a scheduled action",
+			stateName:    "IDLE",
+			requeueAfter: func(*unstructured.Unstructured) time.Duration { return time.Hour },
+			wantState:    state.StateCreated,
+			want:         time.Hour,
+		},
+		{
+			name:         "busy keeps polling earlier",
+			stateName:    "UPDATING",
+			requeueAfter: func(*unstructured.Unstructured) time.Duration { return time.Hour },
+			wantState:    state.StateUpdating,
+			want:         15 * time.Second,
+		},
+		{
+			name:         "busy polls at the scheduled action",
+			stateName:    "UPDATING",
+			requeueAfter: func(*unstructured.Unstructured) time.Duration { return time.Second },
+			wantState:    state.StateUpdating,
+			want:         time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReconciler(nil, Reconciler[string, testResource]{
+				Kind:    "test",
+				Version: "v1",
+				ID: func(u *unstructured.Unstructured) string {
+					return Entry[testResource](u, "v1").Name
+				},
+				Get: func(_ context.Context, _ *atlas.ClientSet, id string) (*testResource, error) {
+					return &testResource{Name: id, StateName: tc.stateName}, nil
+				},
+				StateName:    func(r *testResource) string { return r.StateName },
+				BusyStates:   []string{"UPDATING"},
+				RequeueAfter: tc.requeueAfter,
+			})
+
+			res, err := r.HandleIdle(context.Background(), settledObject(), state.StateCreated)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.NextState != tc.wantState {
+				t.Errorf("got state %s, want %s", res.NextState, tc.wantState)
+			}
+			if res.RequeueAfter != tc.want {
+				t.Errorf("got requeue after %v, want %v", res.RequeueAfter, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandleIdleSections(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		applyErr   error
+		wantStatus metav1.ConditionStatus
+		wantReason string
+		want       time.Duration
+	}{
+		{
+			name:       "applied",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ctrlstate.ReadyReasonSettled,
+		},
+		{
+			name:       "failed section is retried",
+			applyErr:   errors.New("invalid settings"),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ctrlstate.ReadyReasonError,
+			want:       SectionRetryInterval,
+		},
+		{
+			name:       "pending section is retried",
+			applyErr:   fmt.Errorf("%w, resource is UPDATING", ErrSectionPending),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ctrlstate.ReadyReasonPending,
+			want:       SectionRetryInterval,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReconciler(nil, Reconciler[string, testResource]{
+				Kind:    "test",
+				Version: "v1",
+				ID: func(u *unstructured.Unstructured) string {
+					return Entry[testResource](u, "v1").Name
+				},
+				Get: func(_ context.Context, _ *atlas.ClientSet, id string) (*testResource, error) {
+					return &testResource{Name: id}, nil
+				},
+				Sections: []Section[string]{{
+					Field:     "settings",
+					Condition: "SettingsReady",
+					Apply: func(context.Context, *atlas.ClientSet, string, *unstructured.Unstructured) error {
+						return tc.applyErr
+					},
+				}},
+			})
+			u := settledObject()
+			internalunstructured.SetNestedFieldObject(u.Object, map[string]any{"enabled": true}, "spec", "v1", "settings")
+
+			res, err := r.HandleIdle(context.Background(), u, state.StateCreated)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.NextState != state.StateCreated {
+				t.Errorf("got state %s, want %s", res.NextState, state.StateCreated)
+			}
+			if res.RequeueAfter != tc.want {
+				t.Errorf("got requeue after %v, want %v", res.RequeueAfter, tc.want)
+			}
+			condition := meta.FindStatusCondition(status.GetStatus(u).Status.Conditions, "SettingsReady")
+			if condition == nil || condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("got condition %+v, want status %s and reason %s", condition, tc.wantStatus, tc.wantReason)
+			}
+		})
+	}
+}
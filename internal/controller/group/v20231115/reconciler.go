@@ -4,148 +4,145 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
-	ctrlstate "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/result"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
-	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
 )
 
-type Reconciler struct {
-	ctrlstate.StateReconciler
-	Client client.Client
+const version = "v20231115"
+
+// Reconciler manages a project.
+//
+// The optional sections spec.v20231115.maintenanceWindow, settings and limits are applied through their own
+// endpoints once the project exists, each reported in the MaintenanceWindowReady, SettingsReady and LimitsReady
+// conditions. Limits are keyed by limit name, i.e. "atlas.project.deployment.clusters". Removing a section
+// from spec leaves the values in Atlas as they are.
+type Reconciler = generic.Reconciler[string, atlas20231115.Group]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "project",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (string, error) {
+			return generic.Annotation(annotations, "mongodb.com/external-id")
+		},
+
+		ID: func(u *unstructured.Unstructured) string {
+			return generic.Status[atlas20231115.Group](u, version).GetId()
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.Group, error) {
+			params := generic.Params[atlas20231115.CreateProjectApiParams](u, version)
+			params.Group = generic.Entry[atlas20231115.Group](u, version)
+			response, _, err := cs.SdkClient20231115008.ProjectsApi.CreateProjectWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id string) (*atlas20231115.Group, error) {
+			response, _, err := cs.SdkClient20231115008.ProjectsApi.GetProject(ctx, id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) (*atlas20231115.Group, error) {
+			params := &atlas20231115.UpdateProjectApiParams{
+				GroupId:     id,
+				GroupUpdate: generic.Entry[atlas20231115.GroupUpdate](u, version),
+			}
+			response, _, err := cs.SdkClient20231115008.ProjectsApi.UpdateProjectWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id string) error {
+			_, _, err := cs.SdkClient20231115008.ProjectsApi.DeleteProject(ctx, id).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		Sections: []generic.Section[string]{
+			{Field: "maintenanceWindow", Condition: "MaintenanceWindowReady", Apply: applyMaintenanceWindow},
+			{Field: "settings", Condition: "SettingsReady", Apply: applySettings},
+			{Field: "limits", Condition: "LimitsReady", Apply: applyLimits},
+		},
+	})
 }
 
-func (r *Reconciler) HandleImportRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-	id, ok := u.GetAnnotations()["mongodb.com/external-id"]
-	if !ok {
-		return result.Error(state.StateImportRequested, errors.New("missing mongodb.com/external-id"))
-	}
-
-	response, _, err := atlasClients.SdkClient20231115008.ProjectsApi.GetProject(ctx, id).Execute()
+func applyMaintenanceWindow(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) error {
+	desired := generic.SectionValue[atlas20231115.GroupMaintenanceWindow](u, version, "maintenanceWindow")
+	current, _, err := cs.SdkClient20231115008.MaintenanceWindowsApi.GetMaintenanceWindowWithParams(ctx, &atlas20231115.GetMaintenanceWindowApiParams{GroupId: id}).Execute()
 	if err != nil {
-		return result.Error(state.StateImportRequested, fmt.Errorf("failed to get group: %w", err))
+		return err
 	}
 
-	internalunstructured.SetNestedFieldObject(u.Object, response, "spec", "v20231115", "entry")
-
-	patchErr := r.Client.Patch(ctx, u, client.RawPatch(types.MergePatchType, json.MustMarshal(u.Object)))
-	if patchErr != nil {
-		return result.Error(state.StateImportRequested, fmt.Errorf("failed to patch group: %w", err))
+	// startASAP triggers maintenance rather than describing the window, it is only sent along with other changes.
+	window := *desired
+	window.StartASAP = nil
+	if len(json.ChangedFields(&window, current)) == 0 {
+		return nil
 	}
 
-	setStatus(u, response)
-
-	return result.NextState(state.StateImported, "Project imported")
-}
-
-func (r *Reconciler) HandleInitial(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-	params := getParams[atlas20231115.CreateProjectApiParams](u)
-	params.Group = getEntry[atlas20231115.Group](u)
-
-	response, _, err := atlasClients.SdkClient20231115008.ProjectsApi.CreateProjectWithParams(ctx, params).Execute()
-	if err != nil {
-		return result.Error(state.StateInitial, fmt.Errorf("failed to create project: %w", err))
+	params := &atlas20231115.UpdateMaintenanceWindowApiParams{
+		GroupId:                id,
+		GroupMaintenanceWindow: desired,
 	}
-	internalunstructured.SetNestedFieldObject(u.Object, response, "status", "v20231115")
-
-	return result.NextState(state.StateCreated, "Project created.")
+	_, _, err = cs.SdkClient20231115008.MaintenanceWindowsApi.UpdateMaintenanceWindowWithParams(ctx, params).Execute()
+	return err
 }
 
-func (r *Reconciler) HandleImported(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateImported, state.StateUpdating)
-}
-
-func (r *Reconciler) HandleCreated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateCreated, state.StateUpdating)
-}
-
-func (r *Reconciler) HandleUpdated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateUpdated, state.StateUpdating)
-}
-
-func (r *Reconciler) HandleIdle(ctx context.Context, u *unstructured.Unstructured, currentState, finalState state.ResourceState) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-	groupStatus := getStatus[atlas20231115.Group](u)
-	response, _, err := atlasClients.SdkClient20231115008.ProjectsApi.GetProject(ctx, groupStatus.GetId()).Execute()
+func applySettings(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) error {
+	desired := generic.SectionValue[atlas20231115.GroupSettings](u, version, "settings")
+	current, _, err := cs.SdkClient20231115008.ProjectsApi.GetProjectSettingsWithParams(ctx, &atlas20231115.GetProjectSettingsApiParams{GroupId: id}).Execute()
 	if err != nil {
-		return result.Error(currentState, fmt.Errorf("failed to get group: %w", err))
-	}
-	setStatus(u, response)
-
-	s := status.GetStatus(u)
-	stateCondition := meta.FindStatusCondition(s.Status.Conditions, state.StateCondition)
-	if stateCondition.ObservedGeneration == u.GetGeneration() {
-		return result.NextState(currentState, "Upserted group.")
-	}
-
-	entry := getEntry[atlas20231115.GroupUpdate](u)
-	p := &atlas20231115.UpdateProjectApiParams{
-		GroupId:     groupStatus.GetId(),
-		GroupUpdate: entry,
+		return err
 	}
-
-	response, _, err = atlasClients.SdkClient20231115008.ProjectsApi.UpdateProjectWithParams(ctx, p).Execute()
-	if err != nil {
-		return result.Error(currentState, fmt.Errorf("failed to update project: %w", err))
+	if len(json.ChangedFields(desired, current)) == 0 {
+		return nil
 	}
 
-	setStatus(u, response)
-	return result.NextState(state.StateUpdated, "Project updated.")
-}
-
-func (r *Reconciler) HandleDeletionRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-	groupStatus := getStatus[atlas20231115.Group](u)
-	id := groupStatus.Id
-	if id == nil {
-		return result.NextState(state.StateDeleted, "Project deleted.")
+	params := &atlas20231115.UpdateProjectSettingsApiParams{
+		GroupId:       id,
+		GroupSettings: desired,
 	}
-	_, _, err := atlasClients.SdkClient20231115008.ProjectsApi.DeleteProject(ctx, *id).Execute()
-	if atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND") {
-		return result.NextState(state.StateDeleted, "Project deleted.")
-	}
-	if err != nil {
-		return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to delete project: %w", err))
-	}
-
-	return result.NextState(state.StateDeleting, "Deleting project.")
+	_, _, err = cs.SdkClient20231115008.ProjectsApi.UpdateProjectSettingsWithParams(ctx, params).Execute()
+	return err
 }
 
-func (r *Reconciler) HandleDeleting(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	atlasClients := atlas.FromContext(ctx)
-	groupStatus := getStatus[atlas20231115.Group](u)
-	_, _, err := atlasClients.SdkClient20231115008.ProjectsApi.GetProject(ctx, groupStatus.GetId()).Execute()
-	if !atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND") && err != nil {
-		return result.Error(state.StateDeleting, fmt.Errorf("failed to get project: %w", err))
+func applyLimits(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) error {
+	limits := *generic.SectionValue[map[string]atlas20231115.DataFederationLimit](u, version, "limits")
+
+	names := slices.Sorted(maps.Keys(limits))
+	var errs []error
+	for _, name := range names {
+		desired := limits[name]
+		desired.Name = name
+
+		current, _, err := cs.SdkClient20231115008.ProjectsApi.GetProjectLimitWithParams(ctx, &atlas20231115.GetProjectLimitApiParams{GroupId: id, LimitName: name}).Execute()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("limit %s: %w", name, err))
+			continue
+		}
+		if len(json.ChangedFields(&desired, current)) == 0 {
+			continue
+		}
+
+		params := &atlas20231115.SetProjectLimitApiParams{
+			GroupId:             id,
+			LimitName:           name,
+			DataFederationLimit: &desired,
+		}
+		if _, _, err := cs.SdkClient20231115008.ProjectsApi.SetProjectLimitWithParams(ctx, params).Execute(); err != nil {
+			errs = append(errs, fmt.Errorf("limit %s: %w", name, err))
+		}
 	}
-
-	return result.NextState(state.StateDeleted, "Project deleted.")
-}
-
-func getParams[T any](u *unstructured.Unstructured) *T {
-	return json.ConvertNestedField[T](u.Object, "spec", "v20231115", "parameters")
-}
-
-func getEntry[T any](u *unstructured.Unstructured) *T {
-	return json.ConvertNestedField[T](u.Object, "spec", "v20231115", "entry")
-}
-
-func getStatus[T any](u *unstructured.Unstructured) *T {
-	return json.ConvertNestedField[T](u.Object, "status", "v20231115")
-}
-
-func setStatus(u *unstructured.Unstructured, response *atlas20231115.Group) {
-	internalunstructured.SetNestedFieldObject(u.Object, response, "status", "v20231115")
+	return errors.Join(errs...)
 }
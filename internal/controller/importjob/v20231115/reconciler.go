@@ -0,0 +1,63 @@
+package v20231115
+
+import (
+	"context"
+	"errors"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/importer"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler imports the clusters, flex clusters and the IP access list of the project in spec.v20231115.groupRef
+// or spec.v20231115.parameters.groupId into the namespace of the job. Resources managed by an object in any
+// namespace are skipped. The import is repeated whenever the generation changes, status.v20231115 lists the
+// resources of the project and the objects managing them. Deleting the job leaves the objects in place.
+type Reconciler = generic.Reconciler[string, importer.Result]
+
+func NewReconciler(c client.Client) *Reconciler {
+	run := func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*importer.Result, error) {
+		groupID, err := ref.GroupID(ctx, c, u, version)
+		if err != nil {
+			return nil, err
+		}
+		return importer.Import(ctx, c, cs, groupID, u.GetNamespace())
+	}
+
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "import job",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (string, error) {
+			return "", errors.New("import jobs can not be imported")
+		},
+
+		ID: ref.RecordedGroupID,
+
+		Create: run,
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, groupID string) (*importer.Result, error) {
+			return importer.Discover(ctx, c, cs, groupID)
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, groupID string, u *unstructured.Unstructured) (*importer.Result, error) {
+			return run(ctx, cs, u)
+		},
+
+		// imported objects are managed on their own.
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, groupID string) error {
+			return nil
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+	})
+}
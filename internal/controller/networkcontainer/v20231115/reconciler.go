@@ -0,0 +1,98 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages a network peering container of a project in one cloud provider region.
+//
+// Containers can not be deleted while clusters are deployed in them, Atlas reports this by the provisioned flag.
+type Reconciler = generic.Reconciler[atlas20231115.GetPeeringContainerApiParams, atlas20231115.CloudProviderContainer]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "network container",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetPeeringContainerApiParams, error) {
+			var (
+				id  atlas20231115.GetPeeringContainerApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ContainerId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetPeeringContainerApiParams {
+			status := generic.Status[atlas20231115.CloudProviderContainer](u, version)
+			return atlas20231115.GetPeeringContainerApiParams{
+				GroupId:     ref.RecordedGroupID(u),
+				ContainerId: status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.CloudProviderContainer, error) {
+			params := generic.Params[atlas20231115.CreatePeeringContainerApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.CloudProviderContainer = generic.Entry[atlas20231115.CloudProviderContainer](u, version)
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.CreatePeeringContainerWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringContainerApiParams) (*atlas20231115.CloudProviderContainer, error) {
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.GetPeeringContainerWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringContainerApiParams, u *unstructured.Unstructured) (*atlas20231115.CloudProviderContainer, error) {
+			params := &atlas20231115.UpdatePeeringContainerApiParams{
+				GroupId:                id.GroupId,
+				ContainerId:            id.ContainerId,
+				CloudProviderContainer: generic.Entry[atlas20231115.CloudProviderContainer](u, version),
+			}
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.UpdatePeeringContainerWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringContainerApiParams) error {
+			container, _, err := cs.SdkClient20231115008.NetworkPeeringApi.GetPeeringContainerWithParams(ctx, &id).Execute()
+			if err != nil {
+				return err
+			}
+			if container.GetProvisioned() {
+				return fmt.Errorf("network container %s is still used by clusters", id.ContainerId)
+			}
+
+			params := &atlas20231115.DeletePeeringContainerApiParams{
+				GroupId:     id.GroupId,
+				ContainerId: id.ContainerId,
+			}
+			_, _, err = cs.SdkClient20231115008.NetworkPeeringApi.DeletePeeringContainerWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "CLOUD_PROVIDER_CONTAINER_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+	})
+}
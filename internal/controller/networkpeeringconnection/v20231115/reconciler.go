@@ -0,0 +1,136 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages a network peering connection between an Atlas network container and a cloud provider network.
+//
+// The container is referenced by spec.v20231115.containerRef or spec.v20231115.entry.containerId.
+// The connection stays in the Creating state until the peering is available, which for AWS requires accepting the
+// peering request in the peer VPC. Failed connections are reported with the error reported by Atlas.
+type Reconciler = generic.Reconciler[atlas20231115.GetPeeringConnectionApiParams, atlas20231115.BaseNetworkPeeringConnectionSettings]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "network peering connection",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetPeeringConnectionApiParams, error) {
+			var (
+				id  atlas20231115.GetPeeringConnectionApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.PeerId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetPeeringConnectionApiParams {
+			status := generic.Status[atlas20231115.BaseNetworkPeeringConnectionSettings](u, version)
+			return atlas20231115.GetPeeringConnectionApiParams{
+				GroupId: ref.RecordedGroupID(u),
+				PeerId:  status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.BaseNetworkPeeringConnectionSettings, error) {
+			params := generic.Params[atlas20231115.CreatePeeringConnectionApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.BaseNetworkPeeringConnectionSettings = generic.Entry[atlas20231115.BaseNetworkPeeringConnectionSettings](u, version)
+			if err := resolveContainer(ctx, c, u, params.BaseNetworkPeeringConnectionSettings); err != nil {
+				return nil, err
+			}
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.CreatePeeringConnectionWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringConnectionApiParams) (*atlas20231115.BaseNetworkPeeringConnectionSettings, error) {
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.GetPeeringConnectionWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringConnectionApiParams, u *unstructured.Unstructured) (*atlas20231115.BaseNetworkPeeringConnectionSettings, error) {
+			params := &atlas20231115.UpdatePeeringConnectionApiParams{
+				GroupId:                              id.GroupId,
+				PeerId:                               id.PeerId,
+				BaseNetworkPeeringConnectionSettings: generic.Entry[atlas20231115.BaseNetworkPeeringConnectionSettings](u, version),
+			}
+			if err := resolveContainer(ctx, c, u, params.BaseNetworkPeeringConnectionSettings); err != nil {
+				return nil, err
+			}
+			response, _, err := cs.SdkClient20231115008.NetworkPeeringApi.UpdatePeeringConnectionWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPeeringConnectionApiParams) error {
+			params := &atlas20231115.DeletePeeringConnectionApiParams{
+				GroupId: id.GroupId,
+				PeerId:  id.PeerId,
+			}
+			_, _, err := cs.SdkClient20231115008.NetworkPeeringApi.DeletePeeringConnectionWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "PEER_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		StateName:    peeringState,
+		BusyStates:   []string{"INITIATING", "PENDING_ACCEPTANCE", "FINALIZING", "ADDING_PEER", "WAITING_FOR_USER", "TERMINATING", "DELETING"},
+		FailedStates: []string{"FAILED"},
+		ErrorMessage: peeringError,
+	})
+}
+
+// peeringState returns the status name reported for AWS or the status reported for Azure and GCP.
+func peeringState(peering *atlas20231115.BaseNetworkPeeringConnectionSettings) string {
+	if peering.StatusName != nil {
+		return peering.GetStatusName()
+	}
+	return peering.GetStatus()
+}
+
+// peeringError returns the error reported for AWS, Azure or GCP peering connections.
+func peeringError(peering *atlas20231115.BaseNetworkPeeringConnectionSettings) string {
+	for _, msg := range []string{peering.GetErrorStateName(), peering.GetErrorState(), peering.GetErrorMessage()} {
+		if msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// resolveContainer sets the ID of the NetworkContainer referenced in spec.<version>.containerRef on the connection.
+func resolveContainer(ctx context.Context, c client.Reader, u *unstructured.Unstructured, peering *atlas20231115.BaseNetworkPeeringConnectionSettings) error {
+	container, err := ref.Get(ctx, c, u, "NetworkContainer", "spec", version, "containerRef")
+	if err != nil || container == nil {
+		return err
+	}
+
+	containerID, _, _ := unstructured.NestedString(container.Object, "status", "v20231115", "id")
+	if containerID == "" {
+		return fmt.Errorf("referenced NetworkContainer %s is not created yet", container.GetName())
+	}
+	peering.ContainerId = containerID
+	return nil
+}
@@ -2,156 +2,122 @@ package v20231115
 
 import (
 	"context"
+	"slices"
 
 	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
-	ctrlstate "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/state"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/result"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
-	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
-	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/unstructured"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
 )
 
-type Reconciler struct {
-	ctrlstate.StateReconciler
-}
+const version = "v20231115"
 
-func (r *Reconciler) HandleInitial(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateInitial, state.StateCreated)
-}
+// Reconciler manages the whole IP access list of a project, identified by its group ID.
+// Updates replace all existing access list entries with the ones declared in spec, deletion only removes
+// the entries declared in spec so entries added in Atlas since the last update are kept.
+type Reconciler = generic.Reconciler[accessListID, atlas20231115.PaginatedNetworkAccess]
 
-func (r *Reconciler) HandleCreated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateCreated, state.StateUpdated)
+// accessListID identifies the access list of a project.
+type accessListID struct {
+	GroupID string
+	// Entries are the values of the entries declared in spec.v20231115.entry.
+	Entries []string
 }
 
-func (r *Reconciler) HandleUpdated(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	return r.HandleIdle(ctx, u, state.StateUpdated, state.StateUpdated)
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "network permission entry",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (accessListID, error) {
+			groupID, err := generic.Annotation(annotations, "mongodb.com/external-group-id")
+			return accessListID{GroupID: groupID}, err
+		},
+
+		ID: func(u *unstructured.Unstructured) accessListID {
+			id := accessListID{GroupID: generic.Params[atlas20231115.CreateProjectIpAccessListApiParams](u, version).GroupId}
+			if id.GroupID == "" {
+				id.GroupID = u.GetAnnotations()["mongodb.com/external-group-id"]
+			}
+			for _, entry := range *generic.Entry[[]atlas20231115.NetworkPermissionEntry](u, version) {
+				id.Entries = append(id.Entries, entryValue(entry))
+			}
+			return id
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.PaginatedNetworkAccess, error) {
+			params := generic.Params[atlas20231115.CreateProjectIpAccessListApiParams](u, version)
+			return replace(ctx, cs, accessListID{GroupID: params.GroupId}, u)
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id accessListID) (*atlas20231115.PaginatedNetworkAccess, error) {
+			response, _, err := cs.SdkClient20231115008.ProjectIPAccessListApi.ListProjectIpAccessLists(ctx, id.GroupID).Execute()
+			return response, err
+		},
+
+		Update: replace,
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id accessListID) error {
+			return deleteEntries(ctx, cs, id.GroupID, func(value string) bool {
+				return slices.Contains(id.Entries, value)
+			})
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.PaginatedNetworkAccess) any {
+			return response.GetResults()
+		},
+	})
 }
 
-func (r *Reconciler) HandleIdle(ctx context.Context, u *unstructured.Unstructured, currentState, finalState state.ResourceState) (ctrlstate.Result, error) {
-	s := status.GetStatus(u)
-	stateCondition := meta.FindStatusCondition(s.Status.Conditions, state.StateCondition)
-
-	if stateCondition.ObservedGeneration == u.GetGeneration() {
-		return ctrlstate.Result{NextState: finalState}, nil
+func replace(ctx context.Context, cs *atlas.ClientSet, id accessListID, u *unstructured.Unstructured) (*atlas20231115.PaginatedNetworkAccess, error) {
+	if err := deleteEntries(ctx, cs, id.GroupID, func(string) bool { return true }); err != nil {
+		return nil, err
 	}
 
-	atlasClients := atlas.FromContext(ctx)
+	params := generic.Params[atlas20231115.CreateProjectIpAccessListApiParams](u, version)
+	params.GroupId = id.GroupID
+	params.NetworkPermissionEntry = generic.Entry[[]atlas20231115.NetworkPermissionEntry](u, version)
 
-	params := getParams[atlas20231115.CreateProjectIpAccessListApiParams](u)
-	params.NetworkPermissionEntry = getEntry(u)
+	response, _, err := cs.SdkClient20231115008.ProjectIPAccessListApi.CreateProjectIpAccessListWithParams(ctx, params).Execute()
+	return response, err
+}
 
-	listParams := &atlas20231115.ListProjectIpAccessListsApiParams{
-		GroupId: params.GroupId,
-	}
-	entries, _, err := atlasClients.SdkClient20231115008.ProjectIPAccessListApi.ListProjectIpAccessListsWithParams(ctx, listParams).Execute()
+// deleteEntries deletes the access list entries whose value matches.
+func deleteEntries(ctx context.Context, cs *atlas.ClientSet, groupID string, match func(value string) bool) error {
+	entries, _, err := cs.SdkClient20231115008.ProjectIPAccessListApi.ListProjectIpAccessLists(ctx, groupID).Execute()
 	if err != nil {
-		return result.Error(currentState, err)
+		return err
 	}
 
 	for _, entry := range entries.GetResults() {
-		entryValue := ""
-		switch {
-		case entry.AwsSecurityGroup != nil:
-			entryValue = *entry.AwsSecurityGroup
-		case entry.IpAddress != nil:
-			entryValue = *entry.IpAddress
-		case entry.CidrBlock != nil:
-			entryValue = *entry.CidrBlock
-		}
-
-		deleteParams := &atlas20231115.DeleteProjectIpAccessListApiParams{
-			GroupId:    params.GroupId,
-			EntryValue: entryValue,
+		value := entryValue(entry)
+		if !match(value) {
+			continue
 		}
-
-		_, _, err := atlasClients.SdkClient20231115008.ProjectIPAccessListApi.DeleteProjectIpAccessListWithParams(ctx, deleteParams).Execute()
+		_, _, err := cs.SdkClient20231115008.ProjectIPAccessListApi.DeleteProjectIpAccessList(ctx, groupID, value).Execute()
 		if err != nil {
-			return result.Error(currentState, err)
+			return err
 		}
 	}
 
-	response, _, err := atlasClients.SdkClient20231115008.ProjectIPAccessListApi.CreateProjectIpAccessListWithParams(ctx, params).Execute()
-	if err != nil {
-		return result.Error(currentState, err)
-	}
-
-	setStatus(u, response)
-
-	return result.NextState(finalState, "Upserted network permission entry.")
+	return nil
 }
 
-func (r *Reconciler) HandleDeletionRequested(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	//atlasClients := atlas.FromContext(ctx)
-	//
-	//params := &atlas20231115.DeleteClusterApiParams{
-	//	GroupId:     getStatus(u).GetGroupId(),
-	//	ClusterName: getEntry(u).GetName(),
-	//}
-	//
-	//_, err := atlasClients.SdkClient20231115008.ClustersApi.DeleteClusterWithParams(ctx, params).Execute()
-	//switch {
-	//case atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND"):
-	//	return result.Deleted()
-	//case err != nil:
-	//	return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to delete cluster: %w", err))
-	//}
-	//
-	//_, err = r.updateStatus(ctx, u)
-	//if err != nil {
-	//	return result.Error(state.StateDeletionRequested, fmt.Errorf("failed to update status: %w", err))
-	//}
-	//
-	//return result.Deleting()
-	return ctrlstate.Result{}, nil
-}
-
-func (r *Reconciler) HandleDeleting(ctx context.Context, u *unstructured.Unstructured) (ctrlstate.Result, error) {
-	//_, err := r.updateStatus(ctx, u)
-	//
-	//switch {
-	//case atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND"):
-	//	return result.Deleted()
-	//case err != nil:
-	//	return result.Error(state.StateDeleting, fmt.Errorf("failed to update status: %w", err))
-	//}
-	//
-	//return result.Deleting()
-	return ctrlstate.Result{}, nil
-}
-
-func (r *Reconciler) updateStatus(ctx context.Context, u *unstructured.Unstructured) (*atlas20231115.AdvancedClusterDescription, error) {
-	//atlasClients := atlas.FromContext(ctx)
-	//
-	//params := &atlas20231115.GetProjectIpListApiParams{
-	//	GroupId: getStatus(),
-	//}
-	//
-	//response, _, err := atlasClients.SdkClient20231115008.ProjectIPAccessListApi.GetProjectIpListWithParams(ctx, params).Execute()
-	//if err == nil {
-	//	setStatus(u, response)
-	//}
-	//
-	//return response, err
-	return nil, nil
-}
-
-func getParams[T any](u *unstructured.Unstructured) *T {
-	return json.ConvertNestedField[T](u.Object, "spec", "v20231115", "parameters")
-}
-
-func getEntry(u *unstructured.Unstructured) *[]atlas20231115.NetworkPermissionEntry {
-	return json.ConvertNestedField[[]atlas20231115.NetworkPermissionEntry](u.Object, "spec", "v20231115", "entry")
-}
-
-func getStatus(u *unstructured.Unstructured) *[]atlas20231115.NetworkPermissionEntry {
-	return json.ConvertNestedField[[]atlas20231115.NetworkPermissionEntry](u.Object, "status", "v20231115")
-}
-
-func setStatus(u *unstructured.Unstructured, s *atlas20231115.PaginatedNetworkAccess) {
-	internalunstructured.SetNestedFieldObject(u.Object, s, "status", "v20231115")
+// entryValue returns the AWS security group, IP address or CIDR block identifying an access list entry.
+func entryValue(entry atlas20231115.NetworkPermissionEntry) string {
+	switch {
+	case entry.AwsSecurityGroup != nil:
+		return *entry.AwsSecurityGroup
+	case entry.IpAddress != nil:
+		return *entry.IpAddress
+	case entry.CidrBlock != nil:
+		return *entry.CidrBlock
+	}
+	return ""
 }
@@ -0,0 +1,125 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages an online archive of a cluster collection.
+//
+// The cluster is referenced by spec.v20231115.clusterRef or spec.v20231115.parameters.clusterName.
+// Setting spec.v20231115.entry.paused pauses the archive, unsetting it resumes it. The archive stays in the
+// Creating or Updating state while Atlas reports PENDING or PAUSING, orphaned archives are reported as errors.
+// Only the criteria, schedule, data expiration rule and paused flag can be changed after creation.
+type Reconciler = generic.Reconciler[atlas20231115.GetOnlineArchiveApiParams, atlas20231115.BackupOnlineArchive]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "online archive",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetOnlineArchiveApiParams, error) {
+			var (
+				id  atlas20231115.GetOnlineArchiveApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ClusterName, err = generic.Annotation(annotations, "mongodb.com/external-cluster-name"); err != nil {
+				return id, err
+			}
+			if id.ArchiveId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetOnlineArchiveApiParams {
+			status := generic.Status[atlas20231115.BackupOnlineArchive](u, version)
+			return atlas20231115.GetOnlineArchiveApiParams{
+				GroupId:     ref.RecordedGroupID(u),
+				ClusterName: ref.RecordedClusterName(u),
+				ArchiveId:   status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.BackupOnlineArchive, error) {
+			groupID, clusterName, err := ref.Cluster(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			params := &atlas20231115.CreateOnlineArchiveApiParams{
+				GroupId:                   groupID,
+				ClusterName:               clusterName,
+				BackupOnlineArchiveCreate: generic.Entry[atlas20231115.BackupOnlineArchiveCreate](u, version),
+			}
+			response, _, err := cs.SdkClient20231115008.OnlineArchiveApi.CreateOnlineArchiveWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetOnlineArchiveApiParams) (*atlas20231115.BackupOnlineArchive, error) {
+			response, _, err := cs.SdkClient20231115008.OnlineArchiveApi.GetOnlineArchiveWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetOnlineArchiveApiParams, u *unstructured.Unstructured) (*atlas20231115.BackupOnlineArchive, error) {
+			entry := generic.Entry[atlas20231115.BackupOnlineArchiveCreate](u, version)
+			params := &atlas20231115.UpdateOnlineArchiveApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				ArchiveId:   id.ArchiveId,
+				BackupOnlineArchive: &atlas20231115.BackupOnlineArchive{
+					Criteria:           &entry.Criteria,
+					DataExpirationRule: entry.DataExpirationRule,
+					Paused:             atlas20231115.PtrBool(entry.GetPaused()),
+					Schedule:           entry.Schedule,
+				},
+			}
+			response, _, err := cs.SdkClient20231115008.OnlineArchiveApi.UpdateOnlineArchiveWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetOnlineArchiveApiParams) error {
+			params := &atlas20231115.DeleteOnlineArchiveApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				ArchiveId:   id.ArchiveId,
+			}
+			_, _, err := cs.SdkClient20231115008.OnlineArchiveApi.DeleteOnlineArchiveWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "ONLINE_ARCHIVE_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.BackupOnlineArchive) any {
+			entry := *response
+			entry.Id = nil
+			entry.ClusterName = nil
+			entry.GroupId = nil
+			entry.DataSetName = nil
+			entry.State = nil
+			return entry
+		},
+
+		StateName:    (*atlas20231115.BackupOnlineArchive).GetState,
+		BusyStates:   []string{"PENDING", "PAUSING"},
+		FailedStates: []string{"ORPHANED"},
+		// deleted archives remain in Atlas in the DELETED state.
+		SyncDelete: true,
+	})
+}
@@ -0,0 +1,110 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+)
+
+const version = "v20231115"
+
+// Reconciler manages an existing organization, the Organization kind is cluster-scoped.
+//
+// The organization is identified by spec.v20231115.parameters.orgId, its name is set from spec.v20231115.entry.name.
+// The optional section spec.v20231115.settings is applied once the organization was found and reported in the
+// SettingsReady condition. Organizations are neither created nor deleted, deleting the resource leaves the
+// organization in Atlas as it is.
+type Reconciler = generic.Reconciler[string, atlas20231115.AtlasOrganization]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "organization",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (string, error) {
+			return generic.Annotation(annotations, "mongodb.com/external-id")
+		},
+
+		ID: func(u *unstructured.Unstructured) string {
+			return generic.Status[atlas20231115.AtlasOrganization](u, version).GetId()
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.AtlasOrganization, error) {
+			params := generic.Params[atlas20231115.RenameOrganizationApiParams](u, version)
+			if params.OrgId == "" {
+				return nil, fmt.Errorf("spec.%s.parameters.orgId is not set", version)
+			}
+			return rename(ctx, cs, params.OrgId, u)
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id string) (*atlas20231115.AtlasOrganization, error) {
+			response, _, err := cs.SdkClient20231115008.OrganizationsApi.GetOrganizationWithParams(ctx, &atlas20231115.GetOrganizationApiParams{OrgId: id}).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) (*atlas20231115.AtlasOrganization, error) {
+			return rename(ctx, cs, id, u)
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id string) error {
+			return nil
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "ORG_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.AtlasOrganization) any {
+			return atlas20231115.AtlasOrganization{Name: response.Name}
+		},
+
+		Sections: []generic.Section[string]{
+			{Field: "settings", Condition: "SettingsReady", Apply: applySettings},
+		},
+	})
+}
+
+// rename sets the name of the organization from spec if it differs from Atlas.
+func rename(ctx context.Context, cs *atlas.ClientSet, orgID string, u *unstructured.Unstructured) (*atlas20231115.AtlasOrganization, error) {
+	current, _, err := cs.SdkClient20231115008.OrganizationsApi.GetOrganizationWithParams(ctx, &atlas20231115.GetOrganizationApiParams{OrgId: orgID}).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := generic.Entry[atlas20231115.AtlasOrganization](u, version)
+	if entry.Name == "" || entry.Name == current.Name {
+		return current, nil
+	}
+
+	params := &atlas20231115.RenameOrganizationApiParams{
+		OrgId:             orgID,
+		AtlasOrganization: &atlas20231115.AtlasOrganization{Name: entry.Name},
+	}
+	response, _, err := cs.SdkClient20231115008.OrganizationsApi.RenameOrganizationWithParams(ctx, params).Execute()
+	return response, err
+}
+
+func applySettings(ctx context.Context, cs *atlas.ClientSet, id string, u *unstructured.Unstructured) error {
+	desired := generic.SectionValue[atlas20231115.OrganizationSettings](u, version, "settings")
+	current, _, err := cs.SdkClient20231115008.OrganizationsApi.GetOrganizationSettingsWithParams(ctx, &atlas20231115.GetOrganizationSettingsApiParams{OrgId: id}).Execute()
+	if err != nil {
+		return err
+	}
+	if len(json.ChangedFields(desired, current)) == 0 {
+		return nil
+	}
+
+	params := &atlas20231115.UpdateOrganizationSettingsApiParams{
+		OrgId:                id,
+		OrganizationSettings: desired,
+	}
+	_, _, err = cs.SdkClient20231115008.OrganizationsApi.UpdateOrganizationSettingsWithParams(ctx, params).Execute()
+	return err
+}
@@ -0,0 +1,151 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler registers a cloud provider interface endpoint with a private endpoint service.
+//
+// The service is referenced by spec.v20231115.serviceRef or spec.v20231115.parameters.groupId, cloudProvider
+// and endpointServiceId. The endpoint is identified by spec.v20231115.entry.id for AWS and Azure and
+// spec.v20231115.entry.endpointGroupName for GCP.
+type Reconciler = generic.Reconciler[atlas20231115.GetPrivateEndpointApiParams, atlas20231115.PrivateLinkEndpoint]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "private endpoint interface",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetPrivateEndpointApiParams, error) {
+			var (
+				id  atlas20231115.GetPrivateEndpointApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.CloudProvider, err = generic.Annotation(annotations, "mongodb.com/external-cloud-provider"); err != nil {
+				return id, err
+			}
+			if id.EndpointServiceId, err = generic.Annotation(annotations, "mongodb.com/external-endpoint-service-id"); err != nil {
+				return id, err
+			}
+			if id.EndpointId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetPrivateEndpointApiParams {
+			return atlas20231115.GetPrivateEndpointApiParams{
+				GroupId:           ref.RecordedGroupID(u),
+				CloudProvider:     ref.RecordedID(u, "cloudProvider", "mongodb.com/external-cloud-provider"),
+				EndpointServiceId: ref.RecordedID(u, "endpointServiceId", "mongodb.com/external-endpoint-service-id"),
+				EndpointId:        ref.RecordedID(u, "endpointId", "mongodb.com/external-id"),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.PrivateLinkEndpoint, error) {
+			params, err := resolveService(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+			params.CreateEndpointRequest = generic.Entry[atlas20231115.CreateEndpointRequest](u, version)
+
+			endpointID := params.CreateEndpointRequest.GetId()
+			if endpointID == "" {
+				endpointID = params.CreateEndpointRequest.GetEndpointGroupName()
+			}
+			if endpointID == "" {
+				return nil, fmt.Errorf("neither spec.%s.entry.id nor spec.%s.entry.endpointGroupName is set", version, version)
+			}
+			ref.SetID(u, "endpointId", endpointID)
+
+			response, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.CreatePrivateEndpointWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointApiParams) (*atlas20231115.PrivateLinkEndpoint, error) {
+			response, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.GetPrivateEndpointWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointApiParams, u *unstructured.Unstructured) (*atlas20231115.PrivateLinkEndpoint, error) {
+			// interface endpoints can not be changed in Atlas, a new one has to be registered instead.
+			return generic.Status[atlas20231115.PrivateLinkEndpoint](u, version), nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointApiParams) error {
+			params := &atlas20231115.DeletePrivateEndpointApiParams{
+				GroupId:           id.GroupId,
+				CloudProvider:     id.CloudProvider,
+				EndpointId:        id.EndpointId,
+				EndpointServiceId: id.EndpointServiceId,
+			}
+			_, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.DeletePrivateEndpointWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "PRIVATE_ENDPOINT_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "PRIVATE_ENDPOINT_SERVICE_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		StateName:    endpointState,
+		BusyStates:   []string{"INITIATING", "PENDING_ACCEPTANCE", "PENDING", "DELETING"},
+		FailedStates: []string{"FAILED", "REJECTED"},
+		ErrorMessage: (*atlas20231115.PrivateLinkEndpoint).GetErrorMessage,
+	})
+}
+
+// endpointState returns the connection status reported for AWS or the status reported for Azure and GCP.
+func endpointState(endpoint *atlas20231115.PrivateLinkEndpoint) string {
+	if endpoint.ConnectionStatus != nil {
+		return endpoint.GetConnectionStatus()
+	}
+	return endpoint.GetStatus()
+}
+
+// resolveService returns the create parameters of the PrivateEndpointService referenced in spec.<version>.serviceRef,
+// or set in spec.<version>.parameters, and records them in status.
+func resolveService(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (*atlas20231115.CreatePrivateEndpointApiParams, error) {
+	params := generic.Params[atlas20231115.CreatePrivateEndpointApiParams](u, version)
+
+	service, err := ref.Get(ctx, c, u, "PrivateEndpointService", "spec", version, "serviceRef")
+	if err != nil {
+		return nil, err
+	}
+	if service != nil {
+		if !ref.IsReady(service) {
+			return nil, fmt.Errorf("referenced PrivateEndpointService %s is not ready yet", service.GetName())
+		}
+		params.GroupId = ref.RecordedGroupID(service)
+		params.CloudProvider, _, _ = unstructured.NestedString(service.Object, "status", "v20231115", "cloudProvider")
+		params.EndpointServiceId, _, _ = unstructured.NestedString(service.Object, "status", "v20231115", "id")
+		ref.SetID(u, "groupId", params.GroupId)
+	} else {
+		if params.GroupId, err = ref.GroupID(ctx, c, u, version); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.CloudProvider == "" || params.EndpointServiceId == "" {
+		return nil, fmt.Errorf("neither spec.%s.serviceRef nor spec.%s.parameters.cloudProvider and endpointServiceId are set", version, version)
+	}
+
+	ref.SetID(u, "cloudProvider", params.CloudProvider)
+	ref.SetID(u, "endpointServiceId", params.EndpointServiceId)
+	return params, nil
+}
@@ -0,0 +1,103 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages the Atlas side of a private endpoint service in one cloud provider region.
+//
+// Provisioning takes several minutes, the service stays in the Creating state while Atlas reports INITIATING.
+// The name of the service to connect interface endpoints to is reported in status.v20231115.endpointServiceName
+// for AWS, privateLinkServiceName for Azure and serviceAttachmentNames for GCP.
+type Reconciler = generic.Reconciler[atlas20231115.GetPrivateEndpointServiceApiParams, atlas20231115.EndpointService]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "private endpoint service",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetPrivateEndpointServiceApiParams, error) {
+			var (
+				id  atlas20231115.GetPrivateEndpointServiceApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.CloudProvider, err = generic.Annotation(annotations, "mongodb.com/external-cloud-provider"); err != nil {
+				return id, err
+			}
+			if id.EndpointServiceId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetPrivateEndpointServiceApiParams {
+			status := generic.Status[atlas20231115.EndpointService](u, version)
+			return atlas20231115.GetPrivateEndpointServiceApiParams{
+				GroupId:           ref.RecordedGroupID(u),
+				CloudProvider:     status.CloudProvider,
+				EndpointServiceId: status.GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.EndpointService, error) {
+			params := generic.Params[atlas20231115.CreatePrivateEndpointServiceApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			params.CloudProviderEndpointServiceRequest = generic.Entry[atlas20231115.CloudProviderEndpointServiceRequest](u, version)
+			response, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.CreatePrivateEndpointServiceWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointServiceApiParams) (*atlas20231115.EndpointService, error) {
+			response, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.GetPrivateEndpointServiceWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointServiceApiParams, u *unstructured.Unstructured) (*atlas20231115.EndpointService, error) {
+			// private endpoint services can not be changed in Atlas, a new one has to be created in another region.
+			return generic.Status[atlas20231115.EndpointService](u, version), nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetPrivateEndpointServiceApiParams) error {
+			params := &atlas20231115.DeletePrivateEndpointServiceApiParams{
+				GroupId:           id.GroupId,
+				CloudProvider:     id.CloudProvider,
+				EndpointServiceId: id.EndpointServiceId,
+			}
+			_, _, err := cs.SdkClient20231115008.PrivateEndpointServicesApi.DeletePrivateEndpointServiceWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "PRIVATE_ENDPOINT_SERVICE_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.EndpointService) any {
+			return atlas20231115.CloudProviderEndpointServiceRequest{
+				ProviderName: response.CloudProvider,
+				Region:       response.GetRegionName(),
+			}
+		},
+
+		StateName:    (*atlas20231115.EndpointService).GetStatus,
+		BusyStates:   []string{"INITIATING", "DELETING"},
+		FailedStates: []string{"FAILED"},
+		ErrorMessage: (*atlas20231115.EndpointService).GetErrorMessage,
+	})
+}
@@ -0,0 +1,265 @@
+package v20231115
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const version = "v20231115"
+
+// maxItemsPerPage is the largest page size accepted by the Atlas list endpoints.
+const maxItemsPerPage = 500
+
+// Reconciler manages an organization or project API key.
+//
+// Keys referencing a Group in spec.v20231115.groupRef are project keys holding the project roles in
+// spec.v20231115.entry.roles, all other keys are organization keys of spec.v20231115.orgRef or
+// spec.v20231115.parameters.orgId. Atlas returns the private key only on creation, it is written once into
+// a new Secret named in spec.v20231115.privateKeySecretRef with the keys publicKey and privateKey, owned by the
+// resource. The key is deleted again if the Secret can not be written. If the Secret owned by the resource
+// already exists, the key with its public key is recorded instead of creating another one. Imported keys
+// have no Secret.
+type Reconciler = generic.Reconciler[atlas20231115.GetApiKeyApiParams, atlas20231115.ApiKeyUserDetails]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "API key",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetApiKeyApiParams, error) {
+			var (
+				id  atlas20231115.GetApiKeyApiParams
+				err error
+			)
+			if id.OrgId, err = generic.Annotation(annotations, "mongodb.com/external-org-id"); err != nil {
+				return id, err
+			}
+			if id.ApiUserId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetApiKeyApiParams {
+			return atlas20231115.GetApiKeyApiParams{
+				OrgId:     ref.RecordedOrgID(u),
+				ApiUserId: generic.Status[atlas20231115.ApiKeyUserDetails](u, version).GetId(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.ApiKeyUserDetails, error) {
+			secretRef := secret.GetRef(u, "spec", version, "privateKeySecretRef")
+			if secretRef == nil {
+				return nil, fmt.Errorf("spec.%s.privateKeySecretRef is not set", version)
+			}
+
+			orgID, err := keyOrgID(ctx, cs, c, u)
+			if err != nil {
+				return nil, err
+			}
+			publicKey, err := ownedPublicKey(ctx, c, u, secretRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			if publicKey != "" {
+				// the Secret was written by an earlier reconciliation which failed to record the key.
+				return findKey(ctx, cs, orgID, publicKey)
+			}
+
+			entry := generic.Entry[atlas20231115.CreateAtlasOrganizationApiKey](u, version)
+			response, err := create(ctx, cs, c, u, orgID, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := writeSecret(ctx, c, u, secretRef.Name, response); err != nil {
+				// without the private key the key is unusable, it is recreated with the next reconciliation.
+				params := &atlas20231115.DeleteApiKeyApiParams{OrgId: orgID, ApiUserId: response.GetId()}
+				_, _, deleteErr := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.DeleteApiKeyWithParams(ctx, params).Execute()
+				return nil, errors.Join(err, deleteErr)
+			}
+
+			response.PrivateKey = nil
+			return response, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetApiKeyApiParams) (*atlas20231115.ApiKeyUserDetails, error) {
+			response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.GetApiKeyWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetApiKeyApiParams, u *unstructured.Unstructured) (*atlas20231115.ApiKeyUserDetails, error) {
+			entry := generic.Entry[atlas20231115.CreateAtlasOrganizationApiKey](u, version)
+
+			if groupID := ref.ID(u, "groupId"); groupID != "" {
+				params := &atlas20231115.UpdateApiKeyRolesApiParams{
+					GroupId:   groupID,
+					ApiUserId: id.ApiUserId,
+					UpdateAtlasProjectApiKey: &atlas20231115.UpdateAtlasProjectApiKey{
+						Desc:  &entry.Desc,
+						Roles: &entry.Roles,
+					},
+				}
+				response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.UpdateApiKeyRolesWithParams(ctx, params).Execute()
+				return response, err
+			}
+
+			params := &atlas20231115.UpdateApiKeyApiParams{
+				OrgId:     id.OrgId,
+				ApiUserId: id.ApiUserId,
+				UpdateAtlasOrganizationApiKey: &atlas20231115.UpdateAtlasOrganizationApiKey{
+					Desc:  &entry.Desc,
+					Roles: &entry.Roles,
+				},
+			}
+			response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.UpdateApiKeyWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetApiKeyApiParams) error {
+			params := &atlas20231115.DeleteApiKeyApiParams{
+				OrgId:     id.OrgId,
+				ApiUserId: id.ApiUserId,
+			}
+			_, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.DeleteApiKeyWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "API_KEY_NOT_FOUND") || atlas20231115.IsErrorCode(err, "ORG_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.ApiKeyUserDetails) any {
+			entry := atlas20231115.CreateAtlasOrganizationApiKey{Desc: response.GetDesc()}
+			for _, role := range response.GetRoles() {
+				if role.OrgId != nil {
+					entry.Roles = append(entry.Roles, role.GetRoleName())
+				}
+			}
+			return entry
+		},
+	})
+}
+
+// keyOrgID returns the organization ID of the key, the one of the Group in spec.<version>.groupRef for project keys.
+func keyOrgID(ctx context.Context, cs *atlas.ClientSet, c client.Reader, u *unstructured.Unstructured) (string, error) {
+	if ref.Name(u, "spec", version, "groupRef") == "" {
+		return ref.OrgID(ctx, c, u, version)
+	}
+
+	groupID, err := ref.GroupID(ctx, c, u, version)
+	if err != nil {
+		return "", err
+	}
+	group, _, err := cs.SdkClient20231115008.ProjectsApi.GetProject(ctx, groupID).Execute()
+	if err != nil {
+		return "", fmt.Errorf("failed to get project %s: %w", groupID, err)
+	}
+	ref.SetID(u, "orgId", group.OrgId)
+	return group.OrgId, nil
+}
+
+// create creates a project key if spec.<version>.groupRef is set and an organization key otherwise.
+func create(ctx context.Context, cs *atlas.ClientSet, c client.Reader, u *unstructured.Unstructured, orgID string, entry *atlas20231115.CreateAtlasOrganizationApiKey) (*atlas20231115.ApiKeyUserDetails, error) {
+	if ref.Name(u, "spec", version, "groupRef") == "" {
+		params := &atlas20231115.CreateApiKeyApiParams{
+			OrgId:                         orgID,
+			CreateAtlasOrganizationApiKey: entry,
+		}
+		response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.CreateApiKeyWithParams(ctx, params).Execute()
+		return response, err
+	}
+
+	groupID, err := ref.GroupID(ctx, c, u, version)
+	if err != nil {
+		return nil, err
+	}
+	params := &atlas20231115.CreateProjectApiKeyApiParams{
+		GroupId: groupID,
+		CreateAtlasProjectApiKey: &atlas20231115.CreateAtlasProjectApiKey{
+			Desc:  entry.Desc,
+			Roles: entry.Roles,
+		},
+	}
+	response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.CreateProjectApiKeyWithParams(ctx, params).Execute()
+	return response, err
+}
+
+// ownedPublicKey returns the public key of the Secret with the given name if it is owned by u.
+func ownedPublicKey(ctx context.Context, c client.Reader, u *unstructured.Unstructured, name string) (string, error) {
+	s := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: u.GetNamespace(), Name: name}, s); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	for _, owner := range s.GetOwnerReferences() {
+		if owner.UID == u.GetUID() {
+			return string(s.Data["publicKey"]), nil
+		}
+	}
+	return "", nil
+}
+
+// findKey returns the key of the organization with the given public key from all pages Atlas returns.
+func findKey(ctx context.Context, cs *atlas.ClientSet, orgID, publicKey string) (*atlas20231115.ApiKeyUserDetails, error) {
+	for page := 1; ; page++ {
+		params := &atlas20231115.ListApiKeysApiParams{
+			OrgId:        orgID,
+			ItemsPerPage: atlas20231115.PtrInt(maxItemsPerPage),
+			PageNum:      atlas20231115.PtrInt(page),
+		}
+		response, _, err := cs.SdkClient20231115008.ProgrammaticAPIKeysApi.ListApiKeysWithParams(ctx, params).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		for _, key := range response.GetResults() {
+			if key.GetPublicKey() == publicKey {
+				return &key, nil
+			}
+		}
+		if len(response.GetResults()) < maxItemsPerPage || response.TotalCount != nil && page*maxItemsPerPage >= response.GetTotalCount() {
+			return nil, fmt.Errorf("API key %s of the existing secret is missing in Atlas, delete the secret to create a new key", publicKey)
+		}
+	}
+}
+
+// writeSecret creates the Secret holding the public and private key, owned by u.
+// Existing Secrets are never overwritten.
+func writeSecret(ctx context.Context, c client.Client, u *unstructured.Unstructured, name string, key *atlas20231115.ApiKeyUserDetails) error {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: u.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: u.GetAPIVersion(),
+				Kind:       u.GetKind(),
+				Name:       u.GetName(),
+				UID:        u.GetUID(),
+			}},
+		},
+		StringData: map[string]string{
+			"publicKey":  key.GetPublicKey(),
+			"privateKey": key.GetPrivateKey(),
+		},
+	}
+	if err := c.Create(ctx, s); err != nil {
+		return fmt.Errorf("failed to write secret %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package v20231115
+
+import (
+	"context"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// Reconciler manages an Atlas Search or Vector Search index of a cluster.
+//
+// The cluster is referenced by spec.v20231115.clusterRef or spec.v20231115.parameters.clusterName.
+// The index stays in the Creating or Updating state while Atlas builds it, the build status is reported
+// in status.v20231115.status and indexes Atlas failed to build are reported as errors.
+type Reconciler = generic.Reconciler[atlas20231115.GetAtlasSearchIndexApiParams, atlas20231115.ClusterSearchIndex]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "search index",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetAtlasSearchIndexApiParams, error) {
+			var (
+				id  atlas20231115.GetAtlasSearchIndexApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.ClusterName, err = generic.Annotation(annotations, "mongodb.com/external-cluster-name"); err != nil {
+				return id, err
+			}
+			if id.IndexId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetAtlasSearchIndexApiParams {
+			status := generic.Status[atlas20231115.ClusterSearchIndex](u, version)
+			return atlas20231115.GetAtlasSearchIndexApiParams{
+				GroupId:     ref.RecordedGroupID(u),
+				ClusterName: ref.RecordedClusterName(u),
+				IndexId:     status.GetIndexID(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.ClusterSearchIndex, error) {
+			groupID, clusterName, err := ref.Cluster(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			params := &atlas20231115.CreateAtlasSearchIndexApiParams{
+				GroupId:            groupID,
+				ClusterName:        clusterName,
+				ClusterSearchIndex: generic.Entry[atlas20231115.ClusterSearchIndex](u, version),
+			}
+			response, _, err := cs.SdkClient20231115008.AtlasSearchApi.CreateAtlasSearchIndexWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAtlasSearchIndexApiParams) (*atlas20231115.ClusterSearchIndex, error) {
+			response, _, err := cs.SdkClient20231115008.AtlasSearchApi.GetAtlasSearchIndexWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAtlasSearchIndexApiParams, u *unstructured.Unstructured) (*atlas20231115.ClusterSearchIndex, error) {
+			params := &atlas20231115.UpdateAtlasSearchIndexApiParams{
+				GroupId:            id.GroupId,
+				ClusterName:        id.ClusterName,
+				IndexId:            id.IndexId,
+				ClusterSearchIndex: generic.Entry[atlas20231115.ClusterSearchIndex](u, version),
+			}
+			response, _, err := cs.SdkClient20231115008.AtlasSearchApi.UpdateAtlasSearchIndexWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetAtlasSearchIndexApiParams) error {
+			params := &atlas20231115.DeleteAtlasSearchIndexApiParams{
+				GroupId:     id.GroupId,
+				ClusterName: id.ClusterName,
+				IndexId:     id.IndexId,
+			}
+			_, _, err := cs.SdkClient20231115008.AtlasSearchApi.DeleteAtlasSearchIndexWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "ATLAS_SEARCH_INDEX_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "CLUSTER_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20231115.ClusterSearchIndex) any {
+			entry := *response
+			entry.IndexID = nil
+			entry.Status = nil
+			return entry
+		},
+
+		StateName: (*atlas20231115.ClusterSearchIndex).GetStatus,
+		// newer API versions report PENDING and BUILDING instead of IN_PROGRESS.
+		BusyStates:   []string{"PENDING", "BUILDING", "IN_PROGRESS", "MIGRATING"},
+		FailedStates: []string{"FAILED"},
+	})
+}
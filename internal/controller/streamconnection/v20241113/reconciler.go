@@ -0,0 +1,201 @@
+package v20241113
+
+import (
+	"context"
+	"fmt"
+
+	atlas20241113 "go.mongodb.org/atlas-sdk/v20241113001/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const version = "v20241113"
+
+// Reconciler manages a connection of an Atlas Stream Processing instance.
+//
+// The instance is referenced by spec.v20241113.instanceRef or spec.v20241113.parameters.groupId and tenantName.
+// The username and password of Kafka connections are read from the keys of the same name of the Secret referenced
+// in spec.v20241113.authenticationSecretRef. Atlas does not return the password, rotated credentials are detected
+// by the hash of the values last applied.
+type Reconciler = generic.Reconciler[atlas20241113.GetStreamConnectionApiParams, atlas20241113.StreamsConnection]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "stream connection",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20241113.GetStreamConnectionApiParams, error) {
+			var (
+				id  atlas20241113.GetStreamConnectionApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.TenantName, err = generic.Annotation(annotations, "mongodb.com/external-instance-name"); err != nil {
+				return id, err
+			}
+			if id.ConnectionName, err = generic.Annotation(annotations, "mongodb.com/external-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20241113.GetStreamConnectionApiParams {
+			status := generic.Status[atlas20241113.StreamsConnection](u, version)
+			return atlas20241113.GetStreamConnectionApiParams{
+				GroupId:        ref.RecordedGroupID(u),
+				TenantName:     ref.RecordedID(u, "tenantName", "mongodb.com/external-instance-name"),
+				ConnectionName: status.GetName(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20241113.StreamsConnection, error) {
+			groupID, tenantName, err := resolveInstance(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+
+			connection, hash, err := withAuthentication(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+
+			params := &atlas20241113.CreateStreamConnectionApiParams{
+				GroupId:           groupID,
+				TenantName:        tenantName,
+				StreamsConnection: connection,
+			}
+			response, _, err := cs.SdkClient20241113001.StreamsApi.CreateStreamConnectionWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamConnectionApiParams) (*atlas20241113.StreamsConnection, error) {
+			response, _, err := cs.SdkClient20241113001.StreamsApi.GetStreamConnectionWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamConnectionApiParams, u *unstructured.Unstructured) (*atlas20241113.StreamsConnection, error) {
+			connection, hash, err := withAuthentication(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+
+			params := &atlas20241113.UpdateStreamConnectionApiParams{
+				GroupId:           id.GroupId,
+				TenantName:        id.TenantName,
+				ConnectionName:    id.ConnectionName,
+				StreamsConnection: connection,
+			}
+			response, _, err := cs.SdkClient20241113001.StreamsApi.UpdateStreamConnectionWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return response, nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamConnectionApiParams) error {
+			params := &atlas20241113.DeleteStreamConnectionApiParams{
+				GroupId:        id.GroupId,
+				TenantName:     id.TenantName,
+				ConnectionName: id.ConnectionName,
+			}
+			_, _, err := cs.SdkClient20241113001.StreamsApi.DeleteStreamConnectionWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20241113.IsErrorCode(err, "STREAM_CONNECTION_NOT_FOUND") ||
+				atlas20241113.IsErrorCode(err, "STREAM_TENANT_NOT_FOUND_FOR_NAME") ||
+				atlas20241113.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// rotated credentials are applied although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			_, hash, err := withAuthentication(ctx, c, u)
+			if err != nil {
+				return false, err
+			}
+			return hash != secret.AppliedHash(u), nil
+		},
+
+		ImportEntry: func(response *atlas20241113.StreamsConnection) any {
+			entry := *response
+			entry.Links = nil
+			if entry.Authentication != nil {
+				entry.Authentication = &atlas20241113.StreamsKafkaAuthentication{Mechanism: response.Authentication.Mechanism}
+			}
+			return entry
+		},
+	})
+}
+
+// resolveInstance returns the project ID and name of the StreamInstance referenced in spec.<version>.instanceRef,
+// or set in spec.<version>.parameters, and records them in status.
+func resolveInstance(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (string, string, error) {
+	instance, err := ref.Get(ctx, c, u, "StreamInstance", "spec", version, "instanceRef")
+	if err != nil {
+		return "", "", err
+	}
+
+	var groupID, tenantName string
+	if instance != nil {
+		if !ref.IsReady(instance) {
+			return "", "", fmt.Errorf("referenced StreamInstance %s is not ready yet", instance.GetName())
+		}
+		groupID = ref.RecordedGroupID(instance)
+		tenantName, _, _ = unstructured.NestedString(instance.Object, "status", version, "name")
+		ref.SetID(u, "groupId", groupID)
+	} else {
+		if groupID, err = ref.GroupID(ctx, c, u, version); err != nil {
+			return "", "", err
+		}
+		tenantName, _, _ = unstructured.NestedString(u.Object, "spec", version, "parameters", "tenantName")
+	}
+
+	if tenantName == "" {
+		return "", "", fmt.Errorf("neither spec.%s.instanceRef nor spec.%s.parameters.tenantName is set", version, version)
+	}
+
+	ref.SetID(u, "tenantName", tenantName)
+	return groupID, tenantName, nil
+}
+
+// withAuthentication returns the entry with the username and password set from the Secret referenced in
+// spec.<version>.authenticationSecretRef and the hash of the applied values.
+func withAuthentication(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (*atlas20241113.StreamsConnection, string, error) {
+	entry := generic.Entry[atlas20241113.StreamsConnection](u, version)
+
+	ref := secret.GetRef(u, "spec", version, "authenticationSecretRef")
+	if ref == nil {
+		return entry, "", nil
+	}
+	data, err := secret.Data(ctx, c, u.GetNamespace(), ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if entry.Authentication == nil {
+		entry.Authentication = &atlas20241113.StreamsKafkaAuthentication{}
+	}
+	if v, ok := data["username"]; ok {
+		entry.Authentication.Username = atlas20241113.PtrString(string(v))
+	}
+	password, ok := data["password"]
+	if !ok {
+		return nil, "", fmt.Errorf("secret %s has no key %q", ref.Name, "password")
+	}
+	entry.Authentication.Password = atlas20241113.PtrString(string(password))
+
+	return entry, secret.Hash(entry.Authentication.GetUsername(), string(password)), nil
+}
@@ -0,0 +1,101 @@
+package v20241113
+
+import (
+	"context"
+
+	atlas20241113 "go.mongodb.org/atlas-sdk/v20241113001/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20241113"
+
+// Reconciler manages an Atlas Stream Processing instance.
+//
+// Connections of the instance are managed by StreamConnection objects referencing it, only the data process region
+// of an instance can be changed after creation.
+type Reconciler = generic.Reconciler[atlas20241113.GetStreamInstanceApiParams, atlas20241113.StreamsTenant]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "stream instance",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20241113.GetStreamInstanceApiParams, error) {
+			var (
+				id  atlas20241113.GetStreamInstanceApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.TenantName, err = generic.Annotation(annotations, "mongodb.com/external-name"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20241113.GetStreamInstanceApiParams {
+			status := generic.Status[atlas20241113.StreamsTenant](u, version)
+			return atlas20241113.GetStreamInstanceApiParams{
+				GroupId:    ref.RecordedGroupID(u),
+				TenantName: status.GetName(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20241113.StreamsTenant, error) {
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+
+			params := &atlas20241113.CreateStreamInstanceApiParams{
+				GroupId:       groupID,
+				StreamsTenant: generic.Entry[atlas20241113.StreamsTenant](u, version),
+			}
+			response, _, err := cs.SdkClient20241113001.StreamsApi.CreateStreamInstanceWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamInstanceApiParams) (*atlas20241113.StreamsTenant, error) {
+			response, _, err := cs.SdkClient20241113001.StreamsApi.GetStreamInstanceWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamInstanceApiParams, u *unstructured.Unstructured) (*atlas20241113.StreamsTenant, error) {
+			entry := generic.Entry[atlas20241113.StreamsTenant](u, version)
+			params := &atlas20241113.UpdateStreamInstanceApiParams{
+				GroupId:                  id.GroupId,
+				TenantName:               id.TenantName,
+				StreamsDataProcessRegion: entry.DataProcessRegion,
+			}
+			response, _, err := cs.SdkClient20241113001.StreamsApi.UpdateStreamInstanceWithParams(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20241113.GetStreamInstanceApiParams) error {
+			params := &atlas20241113.DeleteStreamInstanceApiParams{
+				GroupId:    id.GroupId,
+				TenantName: id.TenantName,
+			}
+			_, _, err := cs.SdkClient20241113001.StreamsApi.DeleteStreamInstanceWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20241113.IsErrorCode(err, "STREAM_TENANT_NOT_FOUND_FOR_NAME") || atlas20241113.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		ImportEntry: func(response *atlas20241113.StreamsTenant) any {
+			return atlas20241113.StreamsTenant{
+				Name:              response.Name,
+				DataProcessRegion: response.DataProcessRegion,
+				StreamConfig:      response.StreamConfig,
+			}
+		},
+	})
+}
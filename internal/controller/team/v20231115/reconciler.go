@@ -0,0 +1,255 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// maxItemsPerPage is the largest page size accepted by the Atlas list endpoints.
+const maxItemsPerPage = 500
+
+// Reconciler manages an organization team and its members.
+//
+// Members are only managed if spec.v20231115.entry.usernames is set. They are reconciled diff-wise,
+// so members which are already part of the team are left untouched.
+// Project assignments of the team are managed by TeamAssignment objects, a team can be assigned to several Groups.
+type Reconciler = generic.Reconciler[atlas20231115.GetTeamByIdApiParams, atlas20231115.TeamResponse]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "team",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetTeamByIdApiParams, error) {
+			var (
+				id  atlas20231115.GetTeamByIdApiParams
+				err error
+			)
+			if id.OrgId, err = generic.Annotation(annotations, "mongodb.com/external-org-id"); err != nil {
+				return id, err
+			}
+			if id.TeamId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: teamID,
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.TeamResponse, error) {
+			params := generic.Params[atlas20231115.CreateTeamApiParams](u, version)
+			params.Team = generic.Entry[atlas20231115.Team](u, version)
+			if params.Team.Usernames == nil {
+				// Atlas requires at least one member on creation, members are not managed without usernames.
+				return nil, fmt.Errorf("spec.%s.entry.usernames must be set to create a team", version)
+			}
+
+			response, _, err := cs.SdkClient20231115008.TeamsApi.CreateTeamWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			return &atlas20231115.TeamResponse{
+				Id:    response.Id,
+				Links: response.Links,
+				Name:  &response.Name,
+			}, nil
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetTeamByIdApiParams) (*atlas20231115.TeamResponse, error) {
+			response, _, err := cs.SdkClient20231115008.TeamsApi.GetTeamByIdWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetTeamByIdApiParams, u *unstructured.Unstructured) (*atlas20231115.TeamResponse, error) {
+			entry := generic.Entry[atlas20231115.Team](u, version)
+			current := generic.Status[atlas20231115.TeamResponse](u, version)
+
+			response := current
+			if entry.Name != current.GetName() {
+				params := &atlas20231115.RenameTeamApiParams{
+					OrgId:      id.OrgId,
+					TeamId:     id.TeamId,
+					TeamUpdate: &atlas20231115.TeamUpdate{Name: entry.Name},
+				}
+				var err error
+				if response, _, err = cs.SdkClient20231115008.TeamsApi.RenameTeamWithParams(ctx, params).Execute(); err != nil {
+					return nil, err
+				}
+			}
+
+			if entry.Usernames != nil {
+				if err := syncMembers(ctx, cs, id, entry.GetUsernames()); err != nil {
+					return nil, err
+				}
+			}
+
+			return response, nil
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetTeamByIdApiParams) error {
+			if err := checkNotAssigned(ctx, c, id.TeamId); err != nil {
+				return err
+			}
+
+			params := &atlas20231115.DeleteTeamApiParams{
+				OrgId:  id.OrgId,
+				TeamId: id.TeamId,
+			}
+			_, _, err := cs.SdkClient20231115008.TeamsApi.DeleteTeamWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "TEAM_NOT_FOUND") || atlas20231115.IsErrorCode(err, "ORG_NOT_FOUND")
+		},
+
+		// members changed in Atlas are restored although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			entry := generic.Entry[atlas20231115.Team](u, version)
+			if entry.Usernames == nil {
+				return false, nil
+			}
+
+			members, err := listMembers(ctx, atlas.FromContext(ctx), teamID(u))
+			if err != nil {
+				return false, err
+			}
+			toAdd, toRemove := diffMembers(members, entry.GetUsernames())
+			return len(toAdd) > 0 || len(toRemove) > 0, nil
+		},
+
+		RequeueAfter: generic.Resync,
+
+		// members are not managed for imported teams until usernames are set explicitly.
+		ImportEntry: func(response *atlas20231115.TeamResponse) any {
+			return atlas20231115.Team{Name: response.GetName()}
+		},
+	})
+}
+
+// teamID returns the Atlas ID of a created or imported team.
+func teamID(u *unstructured.Unstructured) atlas20231115.GetTeamByIdApiParams {
+	orgID := generic.Params[atlas20231115.CreateTeamApiParams](u, version).OrgId
+	if orgID == "" {
+		orgID = u.GetAnnotations()["mongodb.com/external-org-id"]
+	}
+	return atlas20231115.GetTeamByIdApiParams{
+		OrgId:  orgID,
+		TeamId: generic.Status[atlas20231115.TeamResponse](u, version).GetId(),
+	}
+}
+
+// syncMembers adds missing and removes superfluous members of the team.
+func syncMembers(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetTeamByIdApiParams, usernames []string) error {
+	members, err := listMembers(ctx, cs, id)
+	if err != nil {
+		return err
+	}
+	toAdd, toRemove := diffMembers(members, usernames)
+
+	if len(toAdd) > 0 {
+		users := make([]atlas20231115.AddUserToTeam, 0, len(toAdd))
+		for _, username := range toAdd {
+			user, _, err := cs.SdkClient20231115008.MongoDBCloudUsersApi.GetUserByUsername(ctx, username).Execute()
+			if err != nil {
+				return fmt.Errorf("failed to get user %s: %w", username, err)
+			}
+			users = append(users, atlas20231115.AddUserToTeam{Id: user.GetId()})
+		}
+
+		params := &atlas20231115.AddTeamUserApiParams{
+			OrgId:         id.OrgId,
+			TeamId:        id.TeamId,
+			AddUserToTeam: &users,
+		}
+		if _, _, err := cs.SdkClient20231115008.TeamsApi.AddTeamUserWithParams(ctx, params).Execute(); err != nil {
+			return fmt.Errorf("failed to add team members: %w", err)
+		}
+	}
+
+	for _, user := range toRemove {
+		params := &atlas20231115.RemoveTeamUserApiParams{
+			OrgId:  id.OrgId,
+			TeamId: id.TeamId,
+			UserId: user.GetId(),
+		}
+		if _, err := cs.SdkClient20231115008.TeamsApi.RemoveTeamUserWithParams(ctx, params).Execute(); err != nil {
+			return fmt.Errorf("failed to remove team member %s: %w", user.Username, err)
+		}
+	}
+
+	return nil
+}
+
+// listMembers returns the members of the team from all pages Atlas returns.
+func listMembers(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetTeamByIdApiParams) ([]atlas20231115.CloudAppUser, error) {
+	var members []atlas20231115.CloudAppUser
+	for page := 1; ; page++ {
+		params := &atlas20231115.ListTeamUsersApiParams{
+			OrgId:        id.OrgId,
+			TeamId:       id.TeamId,
+			ItemsPerPage: atlas20231115.PtrInt(maxItemsPerPage),
+			PageNum:      atlas20231115.PtrInt(page),
+		}
+		response, _, err := cs.SdkClient20231115008.TeamsApi.ListTeamUsersWithParams(ctx, params).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list team members: %w", err)
+		}
+
+		members = append(members, response.GetResults()...)
+		if len(response.GetResults()) < maxItemsPerPage || response.TotalCount != nil && len(members) >= response.GetTotalCount() {
+			return members, nil
+		}
+	}
+}
+
+// diffMembers returns the usernames missing in the team and the members not listed in usernames.
+func diffMembers(members []atlas20231115.CloudAppUser, usernames []string) ([]string, []atlas20231115.CloudAppUser) {
+	var (
+		toAdd    []string
+		toRemove []atlas20231115.CloudAppUser
+		current  = make([]string, 0, len(members))
+	)
+	for _, member := range members {
+		current = append(current, member.Username)
+		if !slices.Contains(usernames, member.Username) {
+			toRemove = append(toRemove, member)
+		}
+	}
+	for _, username := range usernames {
+		if !slices.Contains(current, username) {
+			toAdd = append(toAdd, username)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// checkNotAssigned returns an error while TeamAssignment objects still assign the team to a project.
+func checkNotAssigned(ctx context.Context, c client.Reader, teamID string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.GroupVersion, Kind: "TeamAssignmentList"})
+	if err := c.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list team assignments: %w", err)
+	}
+
+	for _, assignment := range list.Items {
+		// imported assignments only carry the team ID in their annotation until they are reconciled.
+		if ref.RecordedID(&assignment, "teamId", "mongodb.com/external-id") == teamID {
+			return fmt.Errorf("team is still assigned by TeamAssignment %s/%s", assignment.GetNamespace(), assignment.GetName())
+		}
+	}
+
+	return nil
+}
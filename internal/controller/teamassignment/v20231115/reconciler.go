@@ -0,0 +1,220 @@
+package v20231115
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+const version = "v20231115"
+
+// maxItemsPerPage is the largest page size accepted by the Atlas list endpoints.
+const maxItemsPerPage = 500
+
+var errNotAssigned = errors.New("team is not assigned to the project")
+
+// Reconciler manages the project roles of one team in one project.
+//
+// The team is referenced by spec.v20231115.teamRef or spec.v20231115.parameters.teamId, the project by
+// spec.v20231115.groupRef or spec.v20231115.parameters.groupId. The same team can be assigned to several
+// Groups by separate TeamAssignment objects, each of them only touches the roles of its own project.
+type Reconciler = generic.Reconciler[atlas20231115.RemoveProjectTeamApiParams, atlas20231115.TeamRole]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "team assignment",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.RemoveProjectTeamApiParams, error) {
+			var (
+				id  atlas20231115.RemoveProjectTeamApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.TeamId, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		// the team ID is the one recorded by teamID on creation, imported assignments carry it in the
+		// mongodb.com/external-id annotation until they are created.
+		ID: func(u *unstructured.Unstructured) atlas20231115.RemoveProjectTeamApiParams {
+			return atlas20231115.RemoveProjectTeamApiParams{
+				GroupId: ref.RecordedGroupID(u),
+				TeamId:  ref.RecordedID(u, "teamId", "mongodb.com/external-id"),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.TeamRole, error) {
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			teamID, err := resolveTeamID(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkNotAssigned(ctx, c, u, groupID, teamID); err != nil {
+				return nil, err
+			}
+
+			id := atlas20231115.RemoveProjectTeamApiParams{GroupId: groupID, TeamId: teamID}
+			roleNames := generic.Entry[atlas20231115.TeamRole](u, version).GetRoleNames()
+
+			// a team already assigned to the project outside of the operator is adopted.
+			current, err := get(ctx, cs, id)
+			switch {
+			case errors.Is(err, errNotAssigned):
+				params := &atlas20231115.AddAllTeamsToProjectApiParams{
+					GroupId:  groupID,
+					TeamRole: &[]atlas20231115.TeamRole{{TeamId: &teamID, RoleNames: &roleNames}},
+				}
+				if _, _, err := cs.SdkClient20231115008.TeamsApi.AddAllTeamsToProjectWithParams(ctx, params).Execute(); err != nil {
+					return nil, err
+				}
+				return get(ctx, cs, id)
+			case err != nil:
+				return nil, err
+			}
+
+			return updateRoles(ctx, cs, id, current, roleNames)
+		},
+
+		Get: get,
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.RemoveProjectTeamApiParams, u *unstructured.Unstructured) (*atlas20231115.TeamRole, error) {
+			current := generic.Status[atlas20231115.TeamRole](u, version)
+			return updateRoles(ctx, cs, id, current, generic.Entry[atlas20231115.TeamRole](u, version).GetRoleNames())
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.RemoveProjectTeamApiParams) error {
+			_, err := cs.SdkClient20231115008.TeamsApi.RemoveProjectTeamWithParams(ctx, &id).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return errors.Is(err, errNotAssigned) ||
+				atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "TEAM_NOT_FOUND") ||
+				atlas20231115.IsErrorCode(err, "NOT_IN_GROUP")
+		},
+
+		// roles changed in Atlas are restored although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			current := generic.Status[atlas20231115.TeamRole](u, version)
+			desired := generic.Entry[atlas20231115.TeamRole](u, version)
+			return !sameRoles(current.GetRoleNames(), desired.GetRoleNames()), nil
+		},
+
+		RequeueAfter: generic.Resync,
+	})
+}
+
+func get(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.RemoveProjectTeamApiParams) (*atlas20231115.TeamRole, error) {
+	for page, listed := 1, 0; ; page++ {
+		params := &atlas20231115.ListProjectTeamsApiParams{
+			GroupId:      id.GroupId,
+			ItemsPerPage: atlas20231115.PtrInt(maxItemsPerPage),
+			PageNum:      atlas20231115.PtrInt(page),
+		}
+		response, _, err := cs.SdkClient20231115008.TeamsApi.ListProjectTeamsWithParams(ctx, params).Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, teamRole := range response.GetResults() {
+			if teamRole.GetTeamId() == id.TeamId {
+				return &teamRole, nil
+			}
+		}
+
+		listed += len(response.GetResults())
+		if len(response.GetResults()) < maxItemsPerPage || response.TotalCount != nil && listed >= response.GetTotalCount() {
+			return nil, fmt.Errorf("%w: team %s, project %s", errNotAssigned, id.TeamId, id.GroupId)
+		}
+	}
+}
+
+// updateRoles replaces the project roles of the team if they differ from the given role names.
+func updateRoles(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.RemoveProjectTeamApiParams, current *atlas20231115.TeamRole, roleNames []string) (*atlas20231115.TeamRole, error) {
+	if sameRoles(current.GetRoleNames(), roleNames) {
+		return current, nil
+	}
+
+	params := &atlas20231115.UpdateTeamRolesApiParams{
+		GroupId:  id.GroupId,
+		TeamId:   id.TeamId,
+		TeamRole: &atlas20231115.TeamRole{RoleNames: &roleNames},
+	}
+	if _, _, err := cs.SdkClient20231115008.TeamsApi.UpdateTeamRolesWithParams(ctx, params).Execute(); err != nil {
+		return nil, err
+	}
+	return get(ctx, cs, id)
+}
+
+// sameRoles reports whether both role sets are equal regardless of their order and duplicates.
+func sameRoles(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// resolveTeamID returns the Atlas ID of the team referenced in spec.<version>.teamRef or set in
+// spec.<version>.parameters.teamId and records it in status.
+func resolveTeamID(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (string, error) {
+	team, err := ref.Get(ctx, c, u, "Team", "spec", version, "teamRef")
+	if err != nil {
+		return "", err
+	}
+
+	var teamID string
+	if team != nil {
+		teamID, _, _ = unstructured.NestedString(team.Object, "status", "v20231115", "id")
+		if teamID == "" {
+			return "", fmt.Errorf("referenced Team %s is not created yet", team.GetName())
+		}
+	} else {
+		teamID, _, _ = unstructured.NestedString(u.Object, "spec", version, "parameters", "teamId")
+	}
+
+	if teamID == "" {
+		return "", fmt.Errorf("neither spec.%s.teamRef nor spec.%s.parameters.teamId is set", version, version)
+	}
+
+	ref.SetID(u, "teamId", teamID)
+	return teamID, nil
+}
+
+// checkNotAssigned returns an error if another TeamAssignment in the namespace already manages
+// the roles of the team in the project, as both would keep overwriting each other.
+func checkNotAssigned(ctx context.Context, c client.Reader, u *unstructured.Unstructured, groupID, teamID string) error {
+	assignments, err := ref.List(ctx, c, u, "TeamAssignment")
+	if err != nil {
+		return err
+	}
+
+	for i := range assignments {
+		other := &assignments[i]
+		if other.GetName() == u.GetName() {
+			continue
+		}
+		if ref.RecordedGroupID(other) == groupID && ref.RecordedID(other, "teamId", "mongodb.com/external-id") == teamID {
+			return fmt.Errorf("team %s is already assigned to project %s by TeamAssignment %s", teamID, groupID, other.GetName())
+		}
+	}
+
+	return nil
+}
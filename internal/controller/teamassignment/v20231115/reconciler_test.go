@@ -0,0 +1,28 @@
+package v20231115
+
+import "testing"
+
+func TestSameRoles(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{name: "empty", want: true},
+		{name: "equal", a: []string{"GROUP_OWNER"}, b: []string{"GROUP_OWNER"}, want: true},
+		{name: "order", a: []string{"GROUP_OWNER", "GROUP_READ_ONLY"}, b: []string{"GROUP_READ_ONLY", "GROUP_OWNER"}, want: true},
+		{name: "duplicates", a: []string{"GROUP_OWNER", "GROUP_OWNER"}, b: []string{"GROUP_OWNER"}, want: true},
+		{name: "duplicates hiding a missing role", a: []string{"GROUP_OWNER", "GROUP_OWNER"}, b: []string{"GROUP_OWNER", "GROUP_READ_ONLY"}},
+		{name: "missing role", a: []string{"GROUP_OWNER"}, b: []string{"GROUP_OWNER", "GROUP_READ_ONLY"}},
+		{name: "different role", a: []string{"GROUP_OWNER"}, b: []string{"GROUP_READ_ONLY"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sameRoles(tc.a, tc.b); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if got := sameRoles(tc.b, tc.a); got != tc.want {
+				t.Errorf("got %v for swapped arguments, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package v20231115
+
+import (
+	"context"
+	"fmt"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/secret"
+)
+
+const version = "v20231115"
+
+// secretFields are the integration fields read from the Secret referenced in spec.v20231115.credentialsSecretRef.
+// Atlas returns them redacted.
+var secretFields = []string{
+	"apiKey",
+	"apiToken",
+	"licenseKey",
+	"microsoftTeamsWebhookUrl",
+	"password",
+	"readToken",
+	"routingKey",
+	"secret",
+	"serviceKey",
+	"url",
+	"writeToken",
+}
+
+// Reconciler manages the integration of a project with a third-party service. A project has at most one
+// integration per type, the type is set in spec.v20231115.parameters.integrationType or spec.v20231115.entry.type.
+//
+// API keys, tokens and webhook URLs are read from the Secret referenced in spec.v20231115.credentialsSecretRef,
+// using the field names as keys, i.e. apiKey for DATADOG. As Atlas returns them redacted, they are tracked by the
+// hash of the values last applied, all other fields are compared against Atlas.
+type Reconciler = generic.Reconciler[atlas20231115.GetThirdPartyIntegrationApiParams, atlas20231115.ThirdPartyIntegration]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "third party integration",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) (atlas20231115.GetThirdPartyIntegrationApiParams, error) {
+			var (
+				id  atlas20231115.GetThirdPartyIntegrationApiParams
+				err error
+			)
+			if id.GroupId, err = generic.Annotation(annotations, "mongodb.com/external-group-id"); err != nil {
+				return id, err
+			}
+			if id.IntegrationType, err = generic.Annotation(annotations, "mongodb.com/external-id"); err != nil {
+				return id, err
+			}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) atlas20231115.GetThirdPartyIntegrationApiParams {
+			status := generic.Status[atlas20231115.ThirdPartyIntegration](u, version)
+			return atlas20231115.GetThirdPartyIntegrationApiParams{
+				GroupId:         ref.RecordedGroupID(u),
+				IntegrationType: status.GetType(),
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*atlas20231115.ThirdPartyIntegration, error) {
+			params := generic.Params[atlas20231115.CreateThirdPartyIntegrationApiParams](u, version)
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+
+			integration, hash, err := withCredentials(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+			if params.IntegrationType == "" {
+				params.IntegrationType = integration.GetType()
+			}
+			if params.IntegrationType == "" {
+				return nil, fmt.Errorf("neither spec.%s.parameters.integrationType nor spec.%s.entry.type is set", version, version)
+			}
+			integration.Type = &params.IntegrationType
+			params.ThirdPartyIntegration = integration
+
+			response, _, err := cs.SdkClient20231115008.ThirdPartyIntegrationsApi.CreateThirdPartyIntegrationWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return find(ctx, cs, response, params.GroupId, params.IntegrationType)
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetThirdPartyIntegrationApiParams) (*atlas20231115.ThirdPartyIntegration, error) {
+			response, _, err := cs.SdkClient20231115008.ThirdPartyIntegrationsApi.GetThirdPartyIntegrationWithParams(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetThirdPartyIntegrationApiParams, u *unstructured.Unstructured) (*atlas20231115.ThirdPartyIntegration, error) {
+			current := generic.Status[atlas20231115.ThirdPartyIntegration](u, version)
+			integration, hash, err := withCredentials(ctx, c, u)
+			if err != nil {
+				return nil, err
+			}
+			if !drifted(generic.Entry[atlas20231115.ThirdPartyIntegration](u, version), current) && hash == secret.AppliedHash(u) {
+				return current, nil
+			}
+
+			integration.Type = &id.IntegrationType
+			params := &atlas20231115.UpdateThirdPartyIntegrationApiParams{
+				GroupId:               id.GroupId,
+				IntegrationType:       id.IntegrationType,
+				ThirdPartyIntegration: integration,
+			}
+			response, _, err := cs.SdkClient20231115008.ThirdPartyIntegrationsApi.UpdateThirdPartyIntegrationWithParams(ctx, params).Execute()
+			if err != nil {
+				return nil, err
+			}
+			secret.SetAppliedHash(u, hash)
+			return find(ctx, cs, response, id.GroupId, id.IntegrationType)
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id atlas20231115.GetThirdPartyIntegrationApiParams) error {
+			params := &atlas20231115.DeleteThirdPartyIntegrationApiParams{
+				GroupId:         id.GroupId,
+				IntegrationType: id.IntegrationType,
+			}
+			_, _, err := cs.SdkClient20231115008.ThirdPartyIntegrationsApi.DeleteThirdPartyIntegrationWithParams(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return atlas20231115.IsErrorCode(err, "INTEGRATION_NOT_FOUND") || atlas20231115.IsErrorCode(err, "GROUP_NOT_FOUND")
+		},
+
+		// integrations changed in Atlas and rotated Secret values are applied although the generation did not change.
+		Changed: func(ctx context.Context, u *unstructured.Unstructured) (bool, error) {
+			if drifted(generic.Entry[atlas20231115.ThirdPartyIntegration](u, version), generic.Status[atlas20231115.ThirdPartyIntegration](u, version)) {
+				return true, nil
+			}
+			_, hash, err := withCredentials(ctx, c, u)
+			if err != nil {
+				return false, err
+			}
+			return hash != secret.AppliedHash(u), nil
+		},
+
+		RequeueAfter: generic.Resync,
+
+		ImportEntry: func(response *atlas20231115.ThirdPartyIntegration) any {
+			entry := *json.Convert[map[string]any](response)
+			delete(entry, "id")
+			for _, field := range secretFields {
+				delete(entry, field)
+			}
+			return entry
+		},
+	})
+}
+
+// withCredentials returns the entry with the fields set from the Secret referenced in
+// spec.v20231115.credentialsSecretRef and the hash of the applied values.
+func withCredentials(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (*atlas20231115.ThirdPartyIntegration, string, error) {
+	entry := generic.Entry[atlas20231115.ThirdPartyIntegration](u, version)
+
+	ref := secret.GetRef(u, "spec", version, "credentialsSecretRef")
+	if ref == nil {
+		return entry, "", nil
+	}
+	data, err := secret.Data(ctx, c, u.GetNamespace(), ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fields := *json.Convert[map[string]any](entry)
+	var values []string
+	for _, field := range secretFields {
+		if v, ok := data[field]; ok {
+			fields[field] = string(v)
+			values = append(values, field, string(v))
+		}
+	}
+	return json.Convert[atlas20231115.ThirdPartyIntegration](fields), secret.Hash(values...), nil
+}
+
+// drifted reports whether a field set in the desired integration differs from Atlas.
+// Secret fields are redacted by Atlas and not considered.
+func drifted(desired, current *atlas20231115.ThirdPartyIntegration) bool {
+	return len(json.ChangedFields(desired, current, secretFields...)) > 0
+}
+
+// find returns the integration of the given type from the list Atlas returns on create and update.
+// The list only holds the first page of integrations, so the integration is read from Atlas if it is missing.
+func find(ctx context.Context, cs *atlas.ClientSet, integrations *atlas20231115.PaginatedIntegration, groupID, integrationType string) (*atlas20231115.ThirdPartyIntegration, error) {
+	for _, integration := range integrations.GetResults() {
+		if integration.GetType() == integrationType {
+			return &integration, nil
+		}
+	}
+	params := &atlas20231115.GetThirdPartyIntegrationApiParams{GroupId: groupID, IntegrationType: integrationType}
+	integration, _, err := cs.SdkClient20231115008.ThirdPartyIntegrationsApi.GetThirdPartyIntegrationWithParams(ctx, params).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("integration %s is missing in the Atlas response: %w", integrationType, err)
+	}
+	return integration, nil
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -23,17 +24,25 @@ type UnstructuredReconciler interface {
 	ReconcileUnstructured(context.Context, ctrl.Request, *unstructured.Unstructured) (reconcile.Result, error)
 }
 
+// Watch is an additional source of reconcile requests, i.e. Secrets referenced by the reconciled objects.
+type Watch struct {
+	Object client.Object
+	// Map returns the requests of objects of the given kind to enqueue for a changed watched object.
+	Map func(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, o client.Object) []reconcile.Request
+}
+
 type Reconciler struct {
 	Reconciler  UnstructuredReconciler
 	GVK         schema.GroupVersionKind
 	Client      client.Client
 	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	Watches     []Watch
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(
 			ObjectForGVK(r.GVK),
 			builder.WithPredicates(
@@ -43,8 +52,15 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		).
 		WithOptions(controller.Options{
 			RateLimiter: r.RateLimiter,
-		}).
-		Complete(r)
+		})
+
+	for _, w := range r.Watches {
+		b = b.Watches(w.Object, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+			return w.Map(ctx, r.Client, r.GVK, o)
+		}))
+	}
+
+	return b.Complete(r)
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -0,0 +1,185 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "0 22 * * 1-5"},
+		{expr: "*/15 0,12 1-7 */2 0"},
+		{expr: "0-30/10 8 * * 7"},
+		{expr: "0 22 * *", wantErr: true},
+		{expr: "0 22 * * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+		{expr: "1-b * * * *", wantErr: true},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := Parse(tc.expr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// daylight saving time starts at midnight in Santiago.
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "step",
+			expr: "*/15 * * * *",
+			from: time.Date(2024, 3, 5, 10, 7, 30, 0, time.UTC),
+			want: time.Date(2024, 3, 5, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly after",
+			expr: "0 22 * * *",
+			from: time.Date(2024, 3, 5, 22, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 6, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "ranged step",
+			expr: "0-30/10 8 * * *",
+			from: time.Date(2024, 3, 5, 8, 5, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 5, 8, 10, 0, 0, time.UTC),
+		},
+		{
+			name: "ranged step wraps to the next day",
+			expr: "0-30/10 8 * * *",
+			from: time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays",
+			expr: "0 9 * * 1-5",
+			from: time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			from: time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month only",
+			expr: "0 0 1 * *",
+			from: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month step",
+			expr: "0 0 */10 * *",
+			from: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week matches the day of week",
+			expr: "0 0 1 * 1",
+			from: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week matches the day of month",
+			expr: "0 0 1 * 1",
+			from: time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "location of from",
+			expr: "0 22 * * *",
+			from: time.Date(2024, 3, 5, 23, 0, 0, 0, time.UTC).In(newYork),
+			want: time.Date(2024, 3, 5, 22, 0, 0, 0, newYork),
+		},
+		{
+			name: "time skipped when daylight saving time starts",
+			expr: "30 2 * * *",
+			from: time.Date(2024, 3, 9, 3, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 11, 2, 30, 0, 0, newYork),
+		},
+		{
+			name: "hourly when daylight saving time starts",
+			expr: "0 * * * *",
+			from: time.Date(2024, 3, 10, 1, 30, 0, 0, newYork),
+			want: time.Date(2024, 3, 10, 3, 0, 0, 0, newYork),
+		},
+		{
+			name: "hour skipped when daylight saving time starts",
+			expr: "0 2 * * *",
+			from: time.Date(2024, 3, 10, 1, 0, 0, 0, newYork),
+			want: time.Date(2024, 3, 11, 2, 0, 0, 0, newYork),
+		},
+		{
+			name: "midnight skipped when daylight saving time starts",
+			expr: "0 * 8 9 *",
+			from: time.Date(2024, 9, 7, 12, 0, 0, 0, santiago),
+			want: time.Date(2024, 9, 8, 1, 0, 0, 0, santiago),
+		},
+		{
+			name: "repeated time when daylight saving time ends",
+			expr: "30 1 * * *",
+			from: time.Date(2024, 11, 3, 0, 0, 0, 0, newYork),
+			want: time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "repeated time matches once",
+			expr: "30 1 * * *",
+			from: time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(newYork),
+			want: time.Date(2024, 11, 4, 1, 30, 0, 0, newYork),
+		},
+		{
+			name: "hourly when daylight saving time ends",
+			expr: "0 * * * *",
+			from: time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(newYork),
+			want: time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := s.Next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	Group        = "atlas.generated.mongodb.com"
+	GroupVersion = "v1"
+)
+
+// Config maps Atlas Admin API operations onto Kubernetes kinds.
+type Config struct {
+	Resources []Resource `json:"resources"`
+}
+
+// Resource describes one generated kind.
+type Resource struct {
+	// Kind is the Kubernetes kind, i.e. "DatabaseUser".
+	Kind string `json:"kind"`
+	// Plural is the lower-case plural resource name. Defaults to the lower-cased kind with an "s" suffix.
+	Plural string `json:"plural,omitempty"`
+	// Name is the human-readable name used in state messages. Defaults to the kind split into lower-case words.
+	Name string `json:"name,omitempty"`
+	// Package is the controller package name below internal/controller. Defaults to the lower-cased kind.
+	Package string `json:"package,omitempty"`
+
+	// Version is the spec and status version key, i.e. "v20231115".
+	Version string `json:"version"`
+	// SDK is the Atlas Go SDK version, i.e. "v20231115008".
+	SDK string `json:"sdk"`
+	// API is the SDK service name, i.e. "DatabaseUsersApi". Defaults to the first tag of the create operation.
+	API string `json:"api,omitempty"`
+
+	Operations Operations `json:"operations"`
+
+	// Import maps get operation path parameters to the mongodb.com/external-* annotations they are read from on import.
+	Import map[string]string `json:"import"`
+	// ID maps get operation path parameters to response properties if their names differ.
+	ID map[string]string `json:"id,omitempty"`
+
+	// SecretRefs are the names of Secret references added next to parameters and entry under spec.<version>.
+	// The reconciler is expected to resolve them, the applied values are tracked by status.appliedSecretHash.
+	SecretRefs []string `json:"secretRefs,omitempty"`
+
+	// Refs maps names of references to other kinds, i.e. "groupRef: Group", added under spec.<version>.
+	// The reconciler is expected to resolve them, the resolved Atlas identifiers are recorded in status.ids.
+	Refs map[string]string `json:"refs,omitempty"`
+
+	// NotFound is the Atlas error code returned for missing resources, i.e. "USER_NOT_FOUND".
+	NotFound string `json:"notFound"`
+	// BusyStates are the values of the stateName property which require polling.
+	BusyStates []string `json:"busyStates,omitempty"`
+
+	// Sections maps names of optional sections added under spec.<version> to the component schema they hold,
+	// i.e. "settings: GroupSettings". A "{}" prefix declares a map of the schema keyed by name. Sections are managed through
+	// separate Atlas endpoints by the reconciler, each reporting its own condition.
+	Sections map[string]string `json:"sections,omitempty"`
+
+	// PauseSchedule adds spec.<version>.pauseSchedule holding cron expressions to pause and resume the resource
+	// and status.pauseSchedule reporting the next planned action. The reconciler is expected to apply them.
+	PauseSchedule bool `json:"pauseSchedule,omitempty"`
+
+	// AutoScaling adds status.autoScaling reporting the instance and disk sizes Atlas manages under auto-scaling.
+	AutoScaling bool `json:"autoScaling,omitempty"`
+
+	// UpgradeTo names the kind the resource can be upgraded to, i.e. Cluster for FlexCluster. It adds
+	// spec.<version>.upgradeTo holding the name and entry of the object the resource is handed over to.
+	// The reconciler is expected to create it.
+	UpgradeTo string `json:"upgradeTo,omitempty"`
+
+	// ClusterScoped marks kinds which are not bound to a namespace, i.e. Organization.
+	ClusterScoped bool `json:"clusterScoped,omitempty"`
+
+	// Status optionally names the component schema stored in status.<version> if it differs from the get response,
+	// i.e. if the get operation lists all resources of a parent.
+	Status string `json:"status,omitempty"`
+	// Custom marks resources which do not map onto plain CRUD operations. Only the CRD and the registrations
+	// are generated, the reconciler in internal/controller/<package>/<version> is written by hand.
+	Custom bool `json:"custom,omitempty"`
+	// ManualCRD marks custom resources not backed by Atlas operations, i.e. ImportJob. Their CRD is written by hand
+	// to config/crd/bases, only the registrations are generated and operations are not needed.
+	ManualCRD bool `json:"manualCRD,omitempty"`
+}
+
+// Operations are the operationIds of the CRUD operations.
+// Custom resources only need create and get, which define the CRD schema.
+type Operations struct {
+	Create string `json:"create"`
+	Get    string `json:"get"`
+	Update string `json:"update"`
+	Delete string `json:"delete"`
+}
+
+// LoadConfig reads a generator configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for i := range cfg.Resources {
+		if err := cfg.Resources[i].defaults(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+func (r *Resource) defaults() error {
+	switch {
+	case r.Kind == "":
+		return fmt.Errorf("resource is missing kind")
+	case r.Version == "":
+		return fmt.Errorf("resource %s is missing version", r.Kind)
+	case r.SDK == "":
+		return fmt.Errorf("resource %s is missing sdk", r.Kind)
+	case r.ManualCRD && !r.Custom:
+		return fmt.Errorf("resource %s with manualCRD must be custom", r.Kind)
+	case !r.ManualCRD && (r.Operations.Create == "" || r.Operations.Get == ""):
+		return fmt.Errorf("resource %s is missing operations", r.Kind)
+	case !r.Custom && (r.Operations.Update == "" || r.Operations.Delete == ""):
+		return fmt.Errorf("resource %s is missing operations", r.Kind)
+	case r.NotFound == "":
+		return fmt.Errorf("resource %s is missing notFound", r.Kind)
+	}
+
+	if r.Plural == "" {
+		r.Plural = strings.ToLower(r.Kind) + "s"
+	}
+	if r.Package == "" {
+		r.Package = strings.ToLower(r.Kind)
+	}
+	if r.Name == "" {
+		r.Name = words(r.Kind)
+	}
+
+	return nil
+}
+
+// words splits a CamelCase identifier into lower-case words, i.e. "DatabaseUser" becomes "database user".
+func words(s string) string {
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && unicode.IsUpper(c) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// pascal upper-cases the first letter of an OpenAPI identifier the same way the SDK generator does.
+func pascal(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,434 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// CRD renders the CustomResourceDefinition of the given resource.
+//
+// The schema follows the version-keyed layout the controllers expect:
+// spec.<version>.parameters holds the create operation path and query parameters,
+// spec.<version>.entry holds the create request body,
+// spec.<version>.<section> holds optional sections managed through separate endpoints and
+// status.<version> holds the get response body next to status.conditions.
+func (g *Generator) CRD(r *Resource) ([]byte, error) {
+	create, err := g.spec.Operation(r.Operations.Create)
+	if err != nil {
+		return nil, err
+	}
+	get, err := g.spec.Operation(r.Operations.Get)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := g.spec.Parameters(create)
+	if err != nil {
+		return nil, err
+	}
+	parameters := apiextensionsv1.JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{},
+	}
+	for _, p := range params {
+		prop, err := g.convert(p.Schema, writeDirection, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		prop.Description = p.Description
+		parameters.Properties[p.Name] = prop
+	}
+
+	requestSchema, err := g.spec.RequestSchema(create, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := g.convert(requestSchema, writeDirection, map[string]bool{})
+	if err != nil {
+		return nil, fmt.Errorf("entry: %w", err)
+	}
+
+	responseSchema, err := g.spec.ResponseSchema(get, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != "" {
+		responseSchema = &Schema{Ref: "#/components/schemas/" + r.Status}
+	}
+	status, err := g.convert(responseSchema, readDirection, map[string]bool{})
+	if err != nil {
+		return nil, fmt.Errorf("status: %w", err)
+	}
+
+	specProperties := map[string]apiextensionsv1.JSONSchemaProps{
+		"parameters": parameters,
+		"entry":      entry,
+	}
+	statusProperties := map[string]apiextensionsv1.JSONSchemaProps{
+		"conditions": conditionsSchema,
+		r.Version:    status,
+	}
+	for name, component := range r.Sections {
+		schema := &Schema{Ref: "#/components/schemas/" + strings.TrimPrefix(component, "{}")}
+		prop, err := g.convert(schema, writeDirection, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("section %s: %w", name, err)
+		}
+		if strings.HasPrefix(component, "{}") {
+			item := prop
+			prop = apiextensionsv1.JSONSchemaProps{
+				Type:                 "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: &item},
+			}
+		}
+		specProperties[name] = prop
+	}
+	for _, name := range r.SecretRefs {
+		specProperties[name] = secretRefSchema
+	}
+	for name, kind := range r.Refs {
+		specProperties[name] = apiextensionsv1.JSONSchemaProps{
+			Type:        "object",
+			Description: fmt.Sprintf("Reference to a %s in the same namespace.", kind),
+			Required:    []string{"name"},
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"name": {Type: "string", Description: fmt.Sprintf("Name of the %s.", kind)},
+			},
+		}
+	}
+	if len(r.Refs) > 0 {
+		statusProperties["ids"] = apiextensionsv1.JSONSchemaProps{
+			Type:        "object",
+			Description: "Atlas identifiers resolved from references.",
+			AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+				Allows: true,
+				Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+			},
+		}
+	}
+	if len(r.SecretRefs) > 0 {
+		statusProperties["appliedSecretHash"] = apiextensionsv1.JSONSchemaProps{
+			Type:        "string",
+			Description: "Hash of the referenced secret values last applied to Atlas.",
+		}
+	}
+
+	if r.PauseSchedule {
+		specProperties["pauseSchedule"] = pauseScheduleSchema
+		statusProperties["pauseSchedule"] = pauseScheduleStatusSchema
+	}
+	if r.AutoScaling {
+		statusProperties["autoScaling"] = autoScalingStatusSchema
+	}
+	if r.UpgradeTo != "" {
+		specProperties["upgradeTo"] = upgradeToSchema(r.UpgradeTo)
+	}
+
+	scope := apiextensionsv1.NamespaceScoped
+	if r.ClusterScoped {
+		scope = apiextensionsv1.ClusterScoped
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: r.Plural + "." + Group,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     r.Kind,
+				ListKind: r.Kind + "List",
+				Plural:   r.Plural,
+				Singular: strings.ToLower(r.Kind),
+			},
+			Scope: scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    GroupVersion,
+				Served:  true,
+				Storage: true,
+				Subresources: &apiextensionsv1.CustomResourceSubresources{
+					Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+				},
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{
+					{Name: "Ready", Type: "string", JSONPath: `.status.conditions[?(@.type=="Ready")].status`},
+					{Name: "State", Type: "string", JSONPath: `.status.conditions[?(@.type=="State")].reason`},
+					{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+				},
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"apiVersion": {Type: "string"},
+							"kind":       {Type: "string"},
+							"metadata":   {Type: "object"},
+							"spec": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									r.Version: {
+										Type:       "object",
+										Properties: specProperties,
+									},
+								},
+							},
+							"status": {
+								Type:       "object",
+								Properties: statusProperties,
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	return marshalManifest(crd)
+}
+
+type direction int
+
+const (
+	// writeDirection omits read-only properties, used for spec.
+	writeDirection direction = iota
+	// readDirection omits write-only properties, used for status.
+	readDirection
+)
+
+// convert translates an OpenAPI schema into a structural CRD schema.
+// Recursive references and polymorphic schemas are preserved as unknown fields.
+func (g *Generator) convert(schema *Schema, d direction, visiting map[string]bool) (apiextensionsv1.JSONSchemaProps, error) {
+	if schema == nil {
+		return preserveUnknownFields(), nil
+	}
+
+	if schema.Ref != "" {
+		name := refName(schema.Ref)
+		if visiting[name] {
+			return preserveUnknownFields(), nil
+		}
+		resolved, err := g.spec.Resolve(schema)
+		if err != nil {
+			return apiextensionsv1.JSONSchemaProps{}, err
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		result, err := g.convert(resolved, d, visiting)
+		if err != nil {
+			return result, err
+		}
+		if schema.Description != "" {
+			result.Description = schema.Description
+		}
+		return result, nil
+	}
+
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		result := preserveUnknownFields()
+		result.Description = schema.Description
+		return result, nil
+	}
+
+	result := apiextensionsv1.JSONSchemaProps{
+		Type:        schema.Type,
+		Format:      schema.Format,
+		Description: schema.Description,
+		Minimum:     schema.Minimum,
+		Maximum:     schema.Maximum,
+		MinLength:   schema.MinLength,
+		MaxLength:   schema.MaxLength,
+		Pattern:     schema.Pattern,
+	}
+	for _, e := range schema.Enum {
+		result.Enum = append(result.Enum, apiextensionsv1.JSON{Raw: e})
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := &Schema{Type: "object", Description: schema.Description, Properties: map[string]*Schema{}}
+		for _, s := range append(schema.AllOf, &Schema{Properties: schema.Properties}) {
+			resolved, err := g.spec.Resolve(s)
+			if err != nil {
+				return apiextensionsv1.JSONSchemaProps{}, err
+			}
+			for k, v := range resolved.Properties {
+				merged.Properties[k] = v
+			}
+		}
+		return g.convert(merged, d, visiting)
+	}
+
+	if schema.Items != nil {
+		items, err := g.convert(schema.Items, d, visiting)
+		if err != nil {
+			return result, err
+		}
+		result.Items = &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &items}
+	}
+
+	if len(schema.Properties) > 0 {
+		result.Type = "object"
+		result.Properties = map[string]apiextensionsv1.JSONSchemaProps{}
+		for name, prop := range schema.Properties {
+			resolved, err := g.spec.Resolve(prop)
+			if err != nil {
+				return result, err
+			}
+			if (d == writeDirection && (prop.ReadOnly || resolved.ReadOnly)) ||
+				(d == readDirection && (prop.WriteOnly || resolved.WriteOnly)) {
+				continue
+			}
+			converted, err := g.convert(prop, d, visiting)
+			if err != nil {
+				return result, fmt.Errorf("%s: %w", name, err)
+			}
+			result.Properties[name] = converted
+		}
+	}
+
+	if len(schema.AdditionalProperties) > 0 {
+		additional := &Schema{}
+		if err := json.Unmarshal(schema.AdditionalProperties, additional); err == nil && (additional.Ref != "" || additional.Type != "") {
+			converted, err := g.convert(additional, d, visiting)
+			if err != nil {
+				return result, err
+			}
+			result.Type = "object"
+			result.AdditionalProperties = &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: &converted}
+		} else {
+			preserve := true
+			result.XPreserveUnknownFields = &preserve
+		}
+	}
+
+	if result.Type == "" || (result.Type == "object" && len(result.Properties) == 0 && result.AdditionalProperties == nil) {
+		preserve := true
+		result.Type = "object"
+		result.XPreserveUnknownFields = &preserve
+	}
+
+	return result, nil
+}
+
+func preserveUnknownFields() apiextensionsv1.JSONSchemaProps {
+	preserve := true
+	return apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: &preserve}
+}
+
+var conditionsSchema = apiextensionsv1.JSONSchemaProps{
+	Type: "array",
+	Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"lastTransitionTime", "message", "reason", "status", "type"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"lastTransitionTime": {Type: "string", Format: "date-time"},
+			"message":            {Type: "string"},
+			"observedGeneration": {Type: "integer", Format: "int64"},
+			"reason":             {Type: "string"},
+			"status":             {Type: "string"},
+			"type":               {Type: "string"},
+		},
+	}},
+}
+
+var secretRefSchema = apiextensionsv1.JSONSchemaProps{
+	Type:        "object",
+	Description: "Reference to a key of a Secret in the same namespace.",
+	Required:    []string{"name"},
+	Properties: map[string]apiextensionsv1.JSONSchemaProps{
+		"name": {Type: "string", Description: "Name of the Secret."},
+		"key":  {Type: "string", Description: "Key within the Secret. Defaults to a resource specific key."},
+	},
+}
+
+var pauseScheduleSchema = apiextensionsv1.JSONSchemaProps{
+	Type:        "object",
+	Description: "Schedule pausing and resuming the resource, overriding the paused flag of the entry.",
+	Required:    []string{"pause", "resume"},
+	Properties: map[string]apiextensionsv1.JSONSchemaProps{
+		"pause":    {Type: "string", Description: `Cron expression "minute hour day-of-month month day-of-week" of the times to pause at.`},
+		"resume":   {Type: "string", Description: `Cron expression "minute hour day-of-month month day-of-week" of the times to resume at.`},
+		"timeZone": {Type: "string", Description: "IANA time zone the cron expressions are evaluated in. Defaults to UTC."},
+	},
+}
+
+var pauseScheduleStatusSchema = apiextensionsv1.JSONSchemaProps{
+	Type:        "object",
+	Description: "Actions of the pause schedule.",
+	Properties: map[string]apiextensionsv1.JSONSchemaProps{
+		"lastAction":     {Type: "string", Description: "Last action applied by the schedule, PAUSE or RESUME."},
+		"lastActionTime": {Type: "string", Format: "date-time", Description: "Time the last action was applied."},
+		"nextAction":     {Type: "string", Description: "Next action planned by the schedule, PAUSE or RESUME."},
+		"nextActionTime": {Type: "string", Format: "date-time", Description: "Time the next action is planned for."},
+		"checkTime":      {Type: "string", Format: "date-time", Description: "Time up to which the schedule was evaluated."},
+	},
+}
+
+var autoScalingStatusSchema = apiextensionsv1.JSONSchemaProps{
+	Type:        "object",
+	Description: "Sizes Atlas manages under active auto-scaling policies. They are left as they are in Atlas on updates.",
+	Properties: map[string]apiextensionsv1.JSONSchemaProps{
+		"diskSizeGB": {Type: "number", Description: "Disk size chosen by disk auto-scaling."},
+		"regions": {
+			Type:        "array",
+			Description: "Instance sizes chosen by compute auto-scaling per region.",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+				Schema: &apiextensionsv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"zoneName":              {Type: "string"},
+						"providerName":          {Type: "string"},
+						"regionName":            {Type: "string"},
+						"instanceSize":          {Type: "string", Description: "Instance size of electable and read-only nodes."},
+						"analyticsInstanceSize": {Type: "string", Description: "Instance size of analytics nodes."},
+					},
+				},
+			},
+		},
+	},
+}
+
+// upgradeToSchema returns the schema of the object of the given kind a resource is upgraded to.
+// The entry is validated by the CRD of the kind once the object is created.
+func upgradeToSchema(kind string) apiextensionsv1.JSONSchemaProps {
+	entry := preserveUnknownFields()
+	entry.Description = fmt.Sprintf("Entry of the %s to create.", kind)
+	return apiextensionsv1.JSONSchemaProps{
+		Type:        "object",
+		Description: fmt.Sprintf("Upgrades the resource to a %s, which takes over managing it in Atlas.", kind),
+		Required:    []string{"entry"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"name":  {Type: "string", Description: fmt.Sprintf("Name of the %s to create. Defaults to the name of this object.", kind)},
+			"entry": entry,
+		},
+	}
+}
+
+// marshalManifest renders the object as YAML document without server populated fields.
+func marshalManifest(obj any) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "status")
+	if metadata, ok := m["metadata"].(map[string]any); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	out, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("---\n"), out...), nil
+}
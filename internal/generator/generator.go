@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Generator scaffolds CRDs and controllers from an Atlas Admin API OpenAPI spec.
+type Generator struct {
+	spec *Spec
+
+	// Root is the repository root all paths are relative to.
+	Root string
+	// Force overwrites existing reconcilers.
+	Force bool
+}
+
+func New(spec *Spec, root string) *Generator {
+	return &Generator{spec: spec, Root: root}
+}
+
+// Generate emits the CRD, the reconciler and the registrations of the given resource.
+// The reconciler of custom resources is not generated, neither is the CRD of resources with ManualCRD.
+func (g *Generator) Generate(r *Resource) error {
+	if r.ManualCRD {
+		if _, err := os.Stat(g.path("config", "crd", "bases", crdFileName(r))); err != nil {
+			return fmt.Errorf("missing CRD of %s: %w", r.Kind, err)
+		}
+	} else {
+		crd, err := g.CRD(r)
+		if err != nil {
+			return fmt.Errorf("failed to generate CRD for %s: %w", r.Kind, err)
+		}
+		if err := g.write(filepath.Join("config", "crd", "bases", crdFileName(r)), crd, true); err != nil {
+			return err
+		}
+	}
+
+	kustomization, err := os.ReadFile(g.path("config", "crd", "kustomization.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to read kustomization: %w", err)
+	}
+	if err := g.write(filepath.Join("config", "crd", "kustomization.yaml"), RegisterCRD(kustomization, r), true); err != nil {
+		return err
+	}
+
+	if !r.Custom {
+		reconciler, err := g.Reconciler(r)
+		if err != nil {
+			return fmt.Errorf("failed to generate reconciler for %s: %w", r.Kind, err)
+		}
+		if err := g.write(filepath.Join("internal", "controller", r.Package, r.Version, "reconciler.go"), reconciler, g.Force); err != nil {
+			return err
+		}
+	}
+
+	main, err := os.ReadFile(g.path("cmd", "main.go"))
+	if err != nil {
+		return fmt.Errorf("failed to read main: %w", err)
+	}
+	main, err = RegisterController(main, r)
+	if err != nil {
+		return fmt.Errorf("failed to register %s: %w", r.Kind, err)
+	}
+	return g.write(filepath.Join("cmd", "main.go"), main, true)
+}
+
+func (g *Generator) path(elem ...string) string {
+	return filepath.Join(append([]string{g.Root}, elem...)...)
+}
+
+func (g *Generator) write(name string, data []byte, overwrite bool) error {
+	path := g.path(name)
+
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		fmt.Printf("skipping existing %s\n", name)
+		return nil
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	fmt.Printf("wrote %s\n", name)
+	return nil
+}
@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Spec is the subset of an OpenAPI 3 document needed to scaffold resources.
+type Spec struct {
+	Paths      map[string]*PathItem `json:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+		Responses  map[string]*Response  `json:"responses"`
+	} `json:"components"`
+}
+
+type PathItem struct {
+	Get        *Operation   `json:"get,omitempty"`
+	Post       *Operation   `json:"post,omitempty"`
+	Put        *Operation   `json:"put,omitempty"`
+	Patch      *Operation   `json:"patch,omitempty"`
+	Delete     *Operation   `json:"delete,omitempty"`
+	Parameters []*Parameter `json:"parameters,omitempty"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref     string                `json:"$ref"`
+	Content map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema  *Schema `json:"schema"`
+	Version string  `json:"x-xgen-version"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []json.RawMessage  `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+// LoadSpec reads an OpenAPI document in YAML or JSON format.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert spec to JSON: %w", err)
+	}
+
+	spec := &Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// Operation returns the operation with the given operationId along with its path level parameters.
+func (s *Spec) Operation(operationID string) (*Operation, error) {
+	for _, item := range s.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil || op.OperationID != operationID {
+				continue
+			}
+			result := *op
+			result.Parameters = append(append([]*Parameter{}, item.Parameters...), op.Parameters...)
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("operation %q not found", operationID)
+}
+
+// Parameters returns the resolved path and query parameters of the given operation.
+func (s *Spec) Parameters(op *Operation) ([]*Parameter, error) {
+	result := make([]*Parameter, 0, len(op.Parameters))
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			resolved, ok := s.Components.Parameters[refName(p.Ref)]
+			if !ok {
+				return nil, fmt.Errorf("unresolved parameter reference %q", p.Ref)
+			}
+			p = resolved
+		}
+		if p.In != "path" && p.In != "query" {
+			continue
+		}
+		// envelope and pretty are transport level flags not modeled in the SDK params.
+		if p.Name == "envelope" || p.Name == "pretty" {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// PathParameters returns the names of the resolved path parameters of the given operation.
+func (s *Spec) PathParameters(op *Operation) ([]string, error) {
+	params, err := s.Parameters(op)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, p := range params {
+		if p.In == "path" {
+			result = append(result, p.Name)
+		}
+	}
+	return result, nil
+}
+
+// RequestSchema returns the request body schema of the given operation for the given API version.
+func (s *Spec) RequestSchema(op *Operation, version string) (*Schema, error) {
+	if op.RequestBody == nil {
+		return nil, fmt.Errorf("operation %q has no request body", op.OperationID)
+	}
+	mt, err := selectVersion(op.RequestBody.Content, version)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", op.OperationID, err)
+	}
+	return mt.Schema, nil
+}
+
+// ResponseSchema returns the successful response schema of the given operation for the given API version.
+// It returns nil if the operation does not return a response body.
+func (s *Spec) ResponseSchema(op *Operation, version string) (*Schema, error) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		response := op.Responses[code]
+		if response.Ref != "" {
+			response = s.Components.Responses[refName(response.Ref)]
+		}
+		if response == nil || len(response.Content) == 0 {
+			continue
+		}
+		mt, err := selectVersion(response.Content, version)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", op.OperationID, err)
+		}
+		return mt.Schema, nil
+	}
+	return nil, nil
+}
+
+// Resolve follows a schema reference. Schemas without a reference are returned as-is.
+func (s *Spec) Resolve(schema *Schema) (*Schema, error) {
+	if schema == nil || schema.Ref == "" {
+		return schema, nil
+	}
+	resolved, ok := s.Components.Schemas[refName(schema.Ref)]
+	if !ok {
+		return nil, fmt.Errorf("unresolved schema reference %q", schema.Ref)
+	}
+	return resolved, nil
+}
+
+// SchemaName returns the component name of a referenced schema which is also the SDK type name.
+func SchemaName(schema *Schema) (string, error) {
+	if schema == nil || schema.Ref == "" {
+		return "", fmt.Errorf("schema is not a component reference")
+	}
+	return refName(schema.Ref), nil
+}
+
+// selectVersion picks the latest content entry whose x-xgen-version is not newer than the given version key.
+// The version key has the form "v20231115" and corresponds to the "2023-11-15" API version.
+func selectVersion(content map[string]*MediaType, version string) (*MediaType, error) {
+	date := strings.TrimPrefix(version, "v")
+	if len(date) == 8 {
+		date = date[0:4] + "-" + date[4:6] + "-" + date[6:8]
+	}
+
+	var (
+		selected        *MediaType
+		selectedVersion string
+	)
+	for _, mt := range content {
+		if mt.Version > date || mt.Version < selectedVersion {
+			continue
+		}
+		selected, selectedVersion = mt, mt.Version
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("no content available for version %s", version)
+	}
+	return selected, nil
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,319 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+type field struct {
+	Name   string
+	Source string
+}
+
+type reconcilerData struct {
+	*Resource
+
+	SDKAlias  string
+	SDKImport string
+	Client    string
+
+	GetMethod    string
+	GetParams    string
+	StatusType   string
+	ImportOrder  []field
+	IDFields     []field
+	IDFromStatus bool
+	IDFromSpec   bool
+
+	CreateMethod string
+	CreateParams string
+	CreateBody   string
+
+	UpdateMethod string
+	UpdateParams string
+	UpdateBody   string
+	UpdateFields []string
+
+	DeleteMethod     string
+	DeleteParams     string
+	DeleteFields     []string
+	DeleteReturnsAny bool
+
+	HasStateName bool
+	GroupRef     bool
+}
+
+// Reconciler renders the generic.Reconciler wiring of the given resource.
+func (g *Generator) Reconciler(r *Resource) ([]byte, error) {
+	data, err := g.reconcilerData(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := reconcilerTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render reconciler: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format reconciler: %w\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+func (g *Generator) reconcilerData(r *Resource) (*reconcilerData, error) {
+	create, err := g.spec.Operation(r.Operations.Create)
+	if err != nil {
+		return nil, err
+	}
+	get, err := g.spec.Operation(r.Operations.Get)
+	if err != nil {
+		return nil, err
+	}
+	update, err := g.spec.Operation(r.Operations.Update)
+	if err != nil {
+		return nil, err
+	}
+	del, err := g.spec.Operation(r.Operations.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &reconcilerData{
+		Resource:  r,
+		SDKAlias:  "atlas" + strings.TrimPrefix(r.Version, "v"),
+		SDKImport: "go.mongodb.org/atlas-sdk/" + r.SDK + "/admin",
+		Client:    "SdkClient" + strings.TrimPrefix(r.SDK, "v"),
+
+		GetMethod:    pascal(get.OperationID) + "WithParams",
+		GetParams:    pascal(get.OperationID) + "ApiParams",
+		CreateMethod: pascal(create.OperationID) + "WithParams",
+		CreateParams: pascal(create.OperationID) + "ApiParams",
+		UpdateMethod: pascal(update.OperationID) + "WithParams",
+		UpdateParams: pascal(update.OperationID) + "ApiParams",
+		DeleteMethod: pascal(del.OperationID) + "WithParams",
+		DeleteParams: pascal(del.OperationID) + "ApiParams",
+	}
+
+	if data.API == "" {
+		if len(create.Tags) == 0 {
+			return nil, fmt.Errorf("operation %q has no tags, set api explicitly", create.OperationID)
+		}
+		data.API = strings.ReplaceAll(create.Tags[0], " ", "") + "Api"
+	}
+
+	responseSchema, err := g.spec.ResponseSchema(get, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if data.StatusType, err = SchemaName(responseSchema); err != nil {
+		return nil, fmt.Errorf("operation %q response: %w", get.OperationID, err)
+	}
+	status, err := g.spec.Resolve(responseSchema)
+	if err != nil {
+		return nil, err
+	}
+	_, data.HasStateName = status.Properties["stateName"]
+	if len(r.BusyStates) > 0 && !data.HasStateName {
+		return nil, fmt.Errorf("resource %s declares busyStates but %s has no stateName property", r.Kind, data.StatusType)
+	}
+
+	requestSchema, err := g.spec.RequestSchema(create, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if data.CreateBody, err = SchemaName(requestSchema); err != nil {
+		return nil, fmt.Errorf("operation %q request: %w", create.OperationID, err)
+	}
+
+	createResponse, err := g.spec.ResponseSchema(create, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if name, _ := SchemaName(createResponse); name != data.StatusType {
+		return nil, fmt.Errorf("operation %q returns %q, expected %q", create.OperationID, name, data.StatusType)
+	}
+
+	requestSchema, err = g.spec.RequestSchema(update, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if data.UpdateBody, err = SchemaName(requestSchema); err != nil {
+		return nil, fmt.Errorf("operation %q request: %w", update.OperationID, err)
+	}
+	updateResponse, err := g.spec.ResponseSchema(update, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	if name, _ := SchemaName(updateResponse); name != data.StatusType {
+		return nil, fmt.Errorf("operation %q returns %q, expected %q", update.OperationID, name, data.StatusType)
+	}
+
+	for _, kind := range r.Refs {
+		data.GroupRef = data.GroupRef || kind == "Group"
+	}
+
+	getFields, err := g.spec.PathParameters(get)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range getFields {
+		annotation, ok := r.Import[name]
+		if !ok {
+			return nil, fmt.Errorf("resource %s is missing an import annotation for %q", r.Kind, name)
+		}
+		data.ImportOrder = append(data.ImportOrder, field{Name: pascal(name), Source: annotation})
+
+		property := name
+		if override, ok := r.ID[name]; ok {
+			property = override
+		}
+		_, inStatus := status.Properties[property]
+		switch {
+		case inStatus:
+			data.IDFromStatus = true
+			data.IDFields = append(data.IDFields, field{Name: pascal(name), Source: "status.Get" + pascal(property) + "()"})
+		case name == "groupId" && data.GroupRef:
+			data.IDFields = append(data.IDFields, field{Name: pascal(name), Source: "ref.RecordedGroupID(u)"})
+		default:
+			data.IDFromSpec = true
+			data.IDFields = append(data.IDFields, field{Name: pascal(name), Source: "params." + pascal(name)})
+		}
+	}
+
+	for _, op := range []struct {
+		op     *Operation
+		fields *[]string
+	}{
+		{op: update, fields: &data.UpdateFields},
+		{op: del, fields: &data.DeleteFields},
+	} {
+		names, err := g.spec.PathParameters(op.op)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !slices.Contains(getFields, name) {
+				return nil, fmt.Errorf("operation %q path parameter %q is not available in %q", op.op.OperationID, name, get.OperationID)
+			}
+			*op.fields = append(*op.fields, pascal(name))
+		}
+	}
+
+	deleteResponse, err := g.spec.ResponseSchema(del, r.Version)
+	if err != nil {
+		return nil, err
+	}
+	data.DeleteReturnsAny = deleteResponse != nil
+
+	return data, nil
+}
+
+var reconcilerTemplate = template.Must(template.New("reconciler").Parse(`package {{ .Version }}
+
+import (
+	"context"
+
+	{{ .SDKAlias }} "{{ .SDKImport }}"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/generic"
+	{{- if .GroupRef }}
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+	{{- end }}
+)
+
+const version = "{{ .Version }}"
+
+type Reconciler = generic.Reconciler[{{ .SDKAlias }}.{{ .GetParams }}, {{ .SDKAlias }}.{{ .StatusType }}]
+
+func NewReconciler(c client.Client) *Reconciler {
+	return generic.NewReconciler(c, Reconciler{
+		Kind:    "{{ .Name }}",
+		Version: version,
+
+		ImportID: func(annotations map[string]string) ({{ .SDKAlias }}.{{ .GetParams }}, error) {
+			var (
+				id  {{ .SDKAlias }}.{{ .GetParams }}
+				err error
+			)
+			{{- range .ImportOrder }}
+			if id.{{ .Name }}, err = generic.Annotation(annotations, "{{ .Source }}"); err != nil {
+				return id, err
+			}
+			{{- end }}
+			return id, nil
+		},
+
+		ID: func(u *unstructured.Unstructured) {{ .SDKAlias }}.{{ .GetParams }} {
+			{{- if .IDFromStatus }}
+			status := generic.Status[{{ .SDKAlias }}.{{ .StatusType }}](u, version)
+			{{- end }}
+			{{- if .IDFromSpec }}
+			params := generic.Params[{{ .SDKAlias }}.{{ .CreateParams }}](u, version)
+			{{- end }}
+			return {{ .SDKAlias }}.{{ .GetParams }}{
+				{{- range .IDFields }}
+				{{ .Name }}: {{ .Source }},
+				{{- end }}
+			}
+		},
+
+		Create: func(ctx context.Context, cs *atlas.ClientSet, u *unstructured.Unstructured) (*{{ .SDKAlias }}.{{ .StatusType }}, error) {
+			params := generic.Params[{{ .SDKAlias }}.{{ .CreateParams }}](u, version)
+			{{- if .GroupRef }}
+			groupID, err := ref.GroupID(ctx, c, u, version)
+			if err != nil {
+				return nil, err
+			}
+			params.GroupId = groupID
+			{{- end }}
+			params.{{ .CreateBody }} = generic.Entry[{{ .SDKAlias }}.{{ .CreateBody }}](u, version)
+			response, _, err := cs.{{ .Client }}.{{ .API }}.{{ .CreateMethod }}(ctx, params).Execute()
+			return response, err
+		},
+
+		Get: func(ctx context.Context, cs *atlas.ClientSet, id {{ .SDKAlias }}.{{ .GetParams }}) (*{{ .SDKAlias }}.{{ .StatusType }}, error) {
+			response, _, err := cs.{{ .Client }}.{{ .API }}.{{ .GetMethod }}(ctx, &id).Execute()
+			return response, err
+		},
+
+		Update: func(ctx context.Context, cs *atlas.ClientSet, id {{ .SDKAlias }}.{{ .GetParams }}, u *unstructured.Unstructured) (*{{ .SDKAlias }}.{{ .StatusType }}, error) {
+			params := &{{ .SDKAlias }}.{{ .UpdateParams }}{
+				{{- range .UpdateFields }}
+				{{ . }}: id.{{ . }},
+				{{- end }}
+				{{ .UpdateBody }}: generic.Entry[{{ .SDKAlias }}.{{ .UpdateBody }}](u, version),
+			}
+			response, _, err := cs.{{ .Client }}.{{ .API }}.{{ .UpdateMethod }}(ctx, params).Execute()
+			return response, err
+		},
+
+		Delete: func(ctx context.Context, cs *atlas.ClientSet, id {{ .SDKAlias }}.{{ .GetParams }}) error {
+			params := &{{ .SDKAlias }}.{{ .DeleteParams }}{
+				{{- range .DeleteFields }}
+				{{ . }}: id.{{ . }},
+				{{- end }}
+			}
+			{{ if .DeleteReturnsAny }}_, {{ end }}_, err := cs.{{ .Client }}.{{ .API }}.{{ .DeleteMethod }}(ctx, params).Execute()
+			return err
+		},
+
+		IsNotFound: func(err error) bool {
+			return {{ .SDKAlias }}.IsErrorCode(err, "{{ .NotFound }}")
+		},
+		{{- if .BusyStates }}
+
+		StateName:  (*{{ .SDKAlias }}.{{ .StatusType }}).GetStateName,
+		BusyStates: []string{ {{- range $i, $s := .BusyStates }}{{ if $i }}, {{ end }}"{{ $s }}"{{ end -}} },
+		{{- end }}
+	})
+}
+`))
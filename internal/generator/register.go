@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const (
+	controllerMarker = "//+generator:scaffold:controller"
+	kustomizeMarker  = "#+kubebuilder:scaffold:crdkustomizeresource"
+	modulePath       = "github.com/mongodb/mongodb-atlas-kubernetes/v3"
+)
+
+// RegisterController adds the controller of the given resource to the manager setup in cmd/main.go.
+// Already registered kinds are left untouched.
+func RegisterController(src []byte, r *Resource) ([]byte, error) {
+	alias := r.Package + strings.TrimPrefix(r.Version, "v")
+	registration := fmt.Sprintf("newController(%q, %s.NewReconciler(mgr.GetClient())),", r.Kind, alias)
+	if bytes.Contains(src, []byte(fmt.Sprintf("newController(%q,", r.Kind))) {
+		return src, nil
+	}
+
+	marker := bytes.Index(src, []byte(controllerMarker))
+	if marker < 0 {
+		return nil, fmt.Errorf("marker %q not found", controllerMarker)
+	}
+	src = insertLine(src, marker, registration)
+
+	// place the import next to the other controller imports, gofmt takes care of the ordering.
+	anchor := bytes.Index(src, []byte(`"`+modulePath+`/internal/controller/`))
+	if anchor < 0 {
+		return nil, fmt.Errorf("no controller import found")
+	}
+	importPath := fmt.Sprintf("%s %q", alias, modulePath+"/internal/controller/"+r.Package+"/"+r.Version)
+	src = insertLine(src, bytes.LastIndexByte(src[:anchor], '\n')+1, importPath)
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format main: %w", err)
+	}
+	return formatted, nil
+}
+
+// RegisterCRD adds the CRD manifest of the given resource to config/crd/kustomization.yaml.
+func RegisterCRD(src []byte, r *Resource) []byte {
+	resource := "- bases/" + crdFileName(r)
+	if bytes.Contains(src, []byte(resource)) {
+		return src
+	}
+
+	marker := bytes.Index(src, []byte(kustomizeMarker))
+	if marker < 0 {
+		return append(src, []byte("\nresources:\n  "+resource+"\n")...)
+	}
+	// skip the blank line separating the resources from the marker.
+	return insertLine(src, bytes.LastIndexByte(src[:marker-1], '\n')+1, "  "+resource)
+}
+
+func crdFileName(r *Resource) string {
+	return Group + "_" + r.Plural + ".yaml"
+}
+
+// insertLine inserts the given line before the line containing offset, keeping its indentation.
+func insertLine(src []byte, offset int, line string) []byte {
+	start := bytes.LastIndexByte(src[:offset], '\n') + 1
+	indent := src[start:start]
+	for i := start; i < len(src) && (src[i] == ' ' || src[i] == '\t'); i++ {
+		indent = src[start : i+1]
+	}
+
+	var buf bytes.Buffer
+	buf.Write(src[:start])
+	buf.Write(indent)
+	buf.WriteString(line)
+	buf.WriteByte('\n')
+	buf.Write(src[start:])
+	return buf.Bytes()
+}
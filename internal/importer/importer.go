@@ -0,0 +1,238 @@
+// Package importer adopts the existing Atlas resources of a project by creating objects carrying the
+// mongodb.com/external-* annotations the controllers import them from.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	atlas20231115 "go.mongodb.org/atlas-sdk/v20231115008/admin"
+	atlas20241113 "go.mongodb.org/atlas-sdk/v20241113001/admin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+// maxItemsPerPage is the largest page size accepted by the Atlas list endpoints.
+const maxItemsPerPage = 500
+
+// kinds are the importable kinds with the version their spec and status are keyed by.
+var kinds = map[string]string{
+	"Cluster":                "v20231115",
+	"FlexCluster":            "v20241113",
+	"NetworkPermissionEntry": "v20231115",
+}
+
+// Resource is an Atlas resource of a project and the object managing it.
+type Resource struct {
+	Kind string `json:"kind"`
+	// ExternalName is the name of the resource in Atlas, empty for the access list.
+	ExternalName string `json:"externalName,omitempty"`
+	// Namespace and Name identify the managing object, they are empty if the resource is not managed.
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Result lists the importable resources of a project.
+type Result struct {
+	GroupID   string     `json:"groupId"`
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// Discover lists the clusters, flex clusters and the IP access list of the project and the objects managing them
+// in any namespace, either created through the operator or imported before.
+func Discover(ctx context.Context, c client.Reader, cs *atlas.ClientSet, groupID string) (*Result, error) {
+	result := &Result{GroupID: groupID}
+
+	flexClusters, err := listFlexClusters(ctx, cs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	flexNames := map[string]bool{}
+	for _, flexCluster := range flexClusters {
+		flexNames[flexCluster.GetName()] = true
+		result.Resources = append(result.Resources, Resource{Kind: "FlexCluster", ExternalName: flexCluster.GetName()})
+	}
+
+	clusters, err := listClusters(ctx, cs, groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		// flex clusters may be listed by the cluster API as well.
+		if flexNames[cluster.GetName()] {
+			continue
+		}
+		result.Resources = append(result.Resources, Resource{Kind: "Cluster", ExternalName: cluster.GetName()})
+	}
+
+	accessList, _, err := cs.SdkClient20231115008.ProjectIPAccessListApi.ListProjectIpAccessLists(ctx, groupID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access list entries: %w", err)
+	}
+	if len(accessList.GetResults()) > 0 {
+		result.Resources = append(result.Resources, Resource{Kind: "NetworkPermissionEntry"})
+	}
+
+	managed, err := managedResources(ctx, c, groupID)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range result.Resources {
+		if key, ok := managed[r]; ok {
+			result.Resources[i].Namespace, result.Resources[i].Name = key.Namespace, key.Name
+		}
+	}
+
+	return result, nil
+}
+
+// managedResources returns the resources of the project managed by an object in any namespace
+// with the key of the object.
+func managedResources(ctx context.Context, c client.Reader, groupID string) (map[Resource]client.ObjectKey, error) {
+	managed := map[Resource]client.ObjectKey{}
+	for kind, version := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.GroupVersion, Kind: kind + "List"})
+		err := c.List(ctx, list)
+		switch {
+		case meta.IsNoMatchError(err):
+			// the CRD of the kind is not installed, so no object manages its resources.
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		for _, item := range list.Items {
+			if externalGroupID(&item, version) != groupID {
+				continue
+			}
+			managed[Resource{Kind: kind, ExternalName: externalName(&item, version)}] = client.ObjectKeyFromObject(&item)
+		}
+	}
+	return managed, nil
+}
+
+// listFlexClusters returns the flex clusters of the project from all pages Atlas returns.
+func listFlexClusters(ctx context.Context, cs *atlas.ClientSet, groupID string) ([]atlas20241113.FlexClusterDescription20241113, error) {
+	var flexClusters []atlas20241113.FlexClusterDescription20241113
+	for page := 1; ; page++ {
+		params := &atlas20241113.ListFlexClustersApiParams{
+			GroupId:      groupID,
+			ItemsPerPage: atlas20241113.PtrInt(maxItemsPerPage),
+			PageNum:      atlas20241113.PtrInt(page),
+		}
+		response, _, err := cs.SdkClient20241113001.FlexClustersApi.ListFlexClustersWithParams(ctx, params).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list flex clusters: %w", err)
+		}
+
+		flexClusters = append(flexClusters, response.GetResults()...)
+		if len(response.GetResults()) < maxItemsPerPage || response.TotalCount != nil && len(flexClusters) >= response.GetTotalCount() {
+			return flexClusters, nil
+		}
+	}
+}
+
+// listClusters returns the clusters of the project from all pages Atlas returns.
+func listClusters(ctx context.Context, cs *atlas.ClientSet, groupID string) ([]atlas20231115.AdvancedClusterDescription, error) {
+	var clusters []atlas20231115.AdvancedClusterDescription
+	for page := 1; ; page++ {
+		params := &atlas20231115.ListClustersApiParams{
+			GroupId:      groupID,
+			ItemsPerPage: atlas20231115.PtrInt(maxItemsPerPage),
+			PageNum:      atlas20231115.PtrInt(page),
+		}
+		response, _, err := cs.SdkClient20231115008.ClustersApi.ListClustersWithParams(ctx, params).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+
+		clusters = append(clusters, response.GetResults()...)
+		if len(response.GetResults()) < maxItemsPerPage || response.TotalCount != nil && len(clusters) >= response.GetTotalCount() {
+			return clusters, nil
+		}
+	}
+}
+
+// Plan returns the objects importing the resources of the result which are not managed yet into the given namespace.
+func Plan(result *Result, namespace string) []*unstructured.Unstructured {
+	var objects []*unstructured.Unstructured
+	for _, r := range result.Resources {
+		if r.Name != "" {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.GroupVersion, Kind: r.Kind})
+		u.SetNamespace(namespace)
+		annotations := map[string]string{"mongodb.com/external-group-id": result.GroupID}
+		if r.ExternalName != "" {
+			u.SetName(objectName(r.ExternalName))
+			annotations["mongodb.com/external-name"] = r.ExternalName
+		} else {
+			u.SetName(objectName("access-list-" + result.GroupID))
+		}
+		u.SetAnnotations(annotations)
+		objects = append(objects, u)
+	}
+	return objects
+}
+
+// Import creates the objects importing the resources of the project which are not managed yet into the given
+// namespace. It returns the resources of the project with the objects managing them, objects failing to be created
+// are reported in the returned error and left unmanaged in the result.
+func Import(ctx context.Context, c client.Client, cs *atlas.ClientSet, groupID, namespace string) (*Result, error) {
+	result, err := Discover(ctx, c, cs, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, u := range Plan(result, namespace) {
+		if err := c.Create(ctx, u); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create %s %s: %w", u.GetKind(), u.GetName(), err))
+			continue
+		}
+		for i, r := range result.Resources {
+			if r.Kind == u.GetKind() && r.ExternalName == u.GetAnnotations()["mongodb.com/external-name"] {
+				result.Resources[i].Namespace, result.Resources[i].Name = u.GetNamespace(), u.GetName()
+			}
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// externalGroupID returns the project ID of the resource managed by u.
+func externalGroupID(u *unstructured.Unstructured, version string) string {
+	if groupID := u.GetAnnotations()["mongodb.com/external-group-id"]; groupID != "" {
+		return groupID
+	}
+	if groupID, _, _ := unstructured.NestedString(u.Object, "spec", version, "parameters", "groupId"); groupID != "" {
+		return groupID
+	}
+	groupID, _, _ := unstructured.NestedString(u.Object, "status", version, "groupId")
+	return groupID
+}
+
+// externalName returns the Atlas name of the resource managed by u.
+func externalName(u *unstructured.Unstructured, version string) string {
+	if name := u.GetAnnotations()["mongodb.com/external-name"]; name != "" {
+		return name
+	}
+	name, _, _ := unstructured.NestedString(u.Object, "spec", version, "entry", "name")
+	return name
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// objectName converts an Atlas name into a valid object name, i.e. Cluster0 into cluster0.
+func objectName(name string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
@@ -0,0 +1,217 @@
+package importer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/ref"
+)
+
+func TestObjectName(t *testing.T) {
+	for _, tc := range []struct {
+		name, in, want string
+	}{
+		{name: "lower case", in: "cluster0", want: "cluster0"},
+		{name: "upper case", in: "Cluster0", want: "cluster0"},
+		{name: "underscores and dots", in: "my_cluster.prod", want: "my-cluster-prod"},
+		{name: "runs of invalid characters", in: "a  _b", want: "a-b"},
+		{name: "leading and trailing invalid characters", in: "_cluster_", want: "cluster"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := objectName(tc.in); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		resources []Resource
+		want      []map[string]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "named resource",
+			resources: []Resource{{Kind: "Cluster", ExternalName: "Cluster0"}},
+			want: []map[string]any{
+				object("Cluster", "cluster0", map[string]any{
+					"mongodb.com/external-group-id": "group",
+					"mongodb.com/external-name":     "Cluster0",
+				}),
+			},
+		},
+		{
+			name:      "access list",
+			resources: []Resource{{Kind: "NetworkPermissionEntry"}},
+			want: []map[string]any{
+				object("NetworkPermissionEntry", "access-list-group", map[string]any{
+					"mongodb.com/external-group-id": "group",
+				}),
+			},
+		},
+		{
+			name: "managed resources are skipped",
+			resources: []Resource{
+				{Kind: "Cluster", ExternalName: "Cluster0", Namespace: "other", Name: "cluster0"},
+				{Kind: "FlexCluster", ExternalName: "Flex0"},
+			},
+			want: []map[string]any{
+				object("FlexCluster", "flex0", map[string]any{
+					"mongodb.com/external-group-id": "group",
+					"mongodb.com/external-name":     "Flex0",
+				}),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []map[string]any
+			for _, u := range Plan(&Result{GroupID: "group", Resources: tc.resources}, "ns") {
+				got = append(got, u.Object)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestManagedResources(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		installed []string
+		objects   []*unstructured.Unstructured
+		want      map[Resource]client.ObjectKey
+	}{
+		{
+			name:      "nothing installed",
+			installed: nil,
+			want:      map[Resource]client.ObjectKey{},
+		},
+		{
+			name:      "imported by annotation",
+			installed: []string{"Cluster"},
+			objects: []*unstructured.Unstructured{
+				managedObject("Cluster", "a", "cluster0", map[string]string{
+					"mongodb.com/external-group-id": "group",
+					"mongodb.com/external-name":     "Cluster0",
+				}, nil),
+			},
+			want: map[Resource]client.ObjectKey{
+				{Kind: "Cluster", ExternalName: "Cluster0"}: {Namespace: "a", Name: "cluster0"},
+			},
+		},
+		{
+			name:      "created with the group in spec",
+			installed: []string{"Cluster"},
+			objects: []*unstructured.Unstructured{
+				managedObject("Cluster", "a", "my-cluster", nil, map[string]any{
+					"spec": map[string]any{"v20231115": map[string]any{
+						"parameters": map[string]any{"groupId": "group"},
+						"entry":      map[string]any{"name": "Cluster1"},
+					}},
+				}),
+			},
+			want: map[Resource]client.ObjectKey{
+				{Kind: "Cluster", ExternalName: "Cluster1"}: {Namespace: "a", Name: "my-cluster"},
+			},
+		},
+		{
+			name:      "created with the group recorded in status",
+			installed: []string{"FlexCluster"},
+			objects: []*unstructured.Unstructured{
+				managedObject("FlexCluster", "b", "flex", nil, map[string]any{
+					"spec":   map[string]any{"v20241113": map[string]any{"entry": map[string]any{"name": "Flex0"}}},
+					"status": map[string]any{"v20241113": map[string]any{"groupId": "group"}},
+				}),
+			},
+			want: map[Resource]client.ObjectKey{
+				{Kind: "FlexCluster", ExternalName: "Flex0"}: {Namespace: "b", Name: "flex"},
+			},
+		},
+		{
+			name:      "access list",
+			installed: []string{"NetworkPermissionEntry"},
+			objects: []*unstructured.Unstructured{
+				managedObject("NetworkPermissionEntry", "a", "access-list", map[string]string{
+					"mongodb.com/external-group-id": "group",
+				}, nil),
+			},
+			want: map[Resource]client.ObjectKey{
+				{Kind: "NetworkPermissionEntry"}: {Namespace: "a", Name: "access-list"},
+			},
+		},
+		{
+			name:      "other projects are skipped",
+			installed: []string{"Cluster", "FlexCluster", "NetworkPermissionEntry"},
+			objects: []*unstructured.Unstructured{
+				managedObject("Cluster", "a", "cluster0", map[string]string{
+					"mongodb.com/external-group-id": "other",
+					"mongodb.com/external-name":     "Cluster0",
+				}, nil),
+				managedObject("FlexCluster", "a", "flex", nil, map[string]any{
+					"spec":   map[string]any{"v20241113": map[string]any{"entry": map[string]any{"name": "Flex0"}}},
+					"status": map[string]any{"v20241113": map[string]any{"groupId": "other"}},
+				}),
+			},
+			want: map[Resource]client.ObjectKey{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			for _, kind := range tc.installed {
+				gv := schema.GroupVersion{Group: ref.Group, Version: ref.GroupVersion}
+				scheme.AddKnownTypeWithName(gv.WithKind(kind), &unstructured.Unstructured{})
+				scheme.AddKnownTypeWithName(gv.WithKind(kind+"List"), &unstructured.UnstructuredList{})
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, u := range tc.objects {
+				builder = builder.WithObjects(u)
+			}
+
+			got, err := managedResources(context.Background(), builder.Build(), "group")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// object returns the object Plan creates for the given kind in namespace ns.
+func object(kind, name string, annotations map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": ref.Group + "/" + ref.GroupVersion,
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":        name,
+			"namespace":   "ns",
+			"annotations": annotations,
+		},
+	}
+}
+
+// managedObject returns an object of the given kind with the given annotations and content.
+func managedObject(kind, namespace, name string, annotations map[string]string, content map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: content}
+	if u.Object == nil {
+		u.Object = map[string]any{}
+	}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: ref.Group, Version: ref.GroupVersion, Kind: kind})
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetAnnotations(annotations)
+	return u
+}
@@ -2,10 +2,17 @@ package json
 
 import (
 	"encoding/json"
+	"reflect"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// Unmarshal parses the JSON encoded data into v.
+func Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
 func MustUnmarshal(data []byte, v interface{}) {
 	err := json.Unmarshal(data, v)
 	if err != nil {
@@ -31,3 +38,49 @@ func ConvertNestedField[T any](obj map[string]interface{}, fields ...string) *T
 	u, _, _ := unstructured.NestedFieldCopy(obj, fields...)
 	return Convert[T](u)
 }
+
+// ChangedFields returns the sorted names of the top-level fields set in desired whose values differ from current,
+// both compared as JSON objects. Only the fields set in desired are compared in nested objects as well, so values
+// defaulted by Atlas don't count as changes. Fields named in ignore are skipped, i.e. secrets Atlas redacts.
+func ChangedFields(desired, current any, ignore ...string) []string {
+	desiredFields := *Convert[map[string]any](desired)
+	currentFields := *Convert[map[string]any](current)
+	var fields []string
+	for field, v := range desiredFields {
+		if !slices.Contains(ignore, field) && !contains(currentFields[field], v) {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// contains reports whether current holds the values set in desired. Arrays are compared element by element.
+func contains(current, desired any) bool {
+	switch desired := desired.(type) {
+	case map[string]any:
+		current, ok := current.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, v := range desired {
+			if !contains(current[key], v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		current, ok := current.([]any)
+		if !ok || len(desired) != len(current) {
+			return false
+		}
+		for i := range desired {
+			if !contains(current[i], desired[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, current)
+	}
+}
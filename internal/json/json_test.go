@@ -0,0 +1,83 @@
+package json
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	type settings struct {
+		Enabled *bool          `json:"enabled,omitempty"`
+		Name    string         `json:"name,omitempty"`
+		Token   string         `json:"token,omitempty"`
+		Tags    []string       `json:"tags,omitempty"`
+		Window  map[string]any `json:"window,omitempty"`
+	}
+	enabled, disabled := true, false
+
+	for _, tc := range []struct {
+		name    string
+		desired any
+		current any
+		ignore  []string
+		want    []string
+	}{
+		{
+			name:    "equal",
+			desired: settings{Enabled: &enabled, Name: "a"},
+			current: settings{Enabled: &enabled, Name: "a"},
+		},
+		{
+			name:    "unset fields are not compared",
+			desired: settings{Name: "a"},
+			current: settings{Enabled: &enabled, Name: "a", Tags: []string{"x"}},
+		},
+		{
+			name:    "changed fields are sorted",
+			desired: settings{Enabled: &disabled, Name: "b"},
+			current: settings{Enabled: &enabled, Name: "a"},
+			want:    []string{"enabled", "name"},
+		},
+		{
+			name:    "ignored fields",
+			desired: settings{Name: "a", Token: "secret"},
+			current: settings{Name: "a", Token: "********"},
+			ignore:  []string{"token"},
+		},
+		{
+			name:    "nested fields defaulted in Atlas",
+			desired: settings{Window: map[string]any{"day": 1}},
+			current: settings{Window: map[string]any{"day": 1, "hour": 2}},
+		},
+		{
+			name:    "nested field changed",
+			desired: settings{Window: map[string]any{"day": 1}},
+			current: settings{Window: map[string]any{"day": 2, "hour": 2}},
+			want:    []string{"window"},
+		},
+		{
+			name:    "array elements",
+			desired: settings{Tags: []string{"x", "y"}},
+			current: settings{Tags: []string{"y", "x"}},
+			want:    []string{"tags"},
+		},
+		{
+			name:    "array length",
+			desired: settings{Tags: []string{"x"}},
+			current: settings{Tags: []string{"x", "y"}},
+			want:    []string{"tags"},
+		},
+		{
+			name:    "maps and structs",
+			desired: map[string]any{"name": "a", "enabled": true},
+			current: &settings{Enabled: &enabled, Name: "b"},
+			want:    []string{"name"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ChangedFields(tc.desired, tc.current, tc.ignore...); !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package ref
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/state"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/status"
+)
+
+const (
+	Group        = "atlas.generated.mongodb.com"
+	GroupVersion = "v1"
+)
+
+// Name returns the name of the object referenced at the given fields, i.e. spec.v20231115.groupRef.name.
+func Name(u *unstructured.Unstructured, fields ...string) string {
+	name, _, _ := unstructured.NestedString(u.Object, append(fields, "name")...)
+	return name
+}
+
+// Get returns the object of the given kind referenced at the given fields in the namespace of u,
+// cluster-scoped kinds like Organization are looked up by name only. It returns nil if no reference is set.
+func Get(ctx context.Context, c client.Reader, u *unstructured.Unstructured, kind string, fields ...string) (*unstructured.Unstructured, error) {
+	name := Name(u, fields...)
+	if name == "" {
+		return nil, nil
+	}
+
+	referenced := &unstructured.Unstructured{}
+	referenced.SetGroupVersionKind(schema.GroupVersionKind{Group: Group, Version: GroupVersion, Kind: kind})
+	if err := c.Get(ctx, types.NamespacedName{Namespace: u.GetNamespace(), Name: name}, referenced); err != nil {
+		return nil, fmt.Errorf("failed to get referenced %s %s: %w", kind, name, err)
+	}
+	return referenced, nil
+}
+
+// List returns all objects of the given kind in the namespace of u.
+func List(ctx context.Context, c client.Reader, u *unstructured.Unstructured, kind string) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: Group, Version: GroupVersion, Kind: kind + "List"})
+	if err := c.List(ctx, list, client.InNamespace(u.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+	return list.Items, nil
+}
+
+// IsReady reports whether the given object has settled in Atlas.
+func IsReady(u *unstructured.Unstructured) bool {
+	return meta.IsStatusConditionTrue(status.GetStatus(u).Status.Conditions, state.ReadyCondition)
+}
+
+// GroupID returns the Atlas project ID of u, either of the Group referenced in spec.<version>.groupRef
+// or spec.<version>.parameters.groupId. The ID is recorded in status so it stays available for
+// deletion after the referenced Group is gone.
+func GroupID(ctx context.Context, c client.Reader, u *unstructured.Unstructured, version string) (string, error) {
+	group, err := Get(ctx, c, u, "Group", "spec", version, "groupRef")
+	if err != nil {
+		return "", err
+	}
+
+	var groupID string
+	if group != nil {
+		groupID, _, _ = unstructured.NestedString(group.Object, "status", "v20231115", "id")
+		if groupID == "" {
+			return "", fmt.Errorf("referenced Group %s is not created yet", group.GetName())
+		}
+	} else {
+		groupID, _, _ = unstructured.NestedString(u.Object, "spec", version, "parameters", "groupId")
+	}
+
+	if groupID == "" {
+		return "", fmt.Errorf("neither spec.%s.groupRef nor spec.%s.parameters.groupId is set", version, version)
+	}
+
+	SetID(u, "groupId", groupID)
+	return groupID, nil
+}
+
+// RecordedGroupID returns the Atlas project ID recorded by GroupID,
+// falling back to the mongodb.com/external-group-id annotation of imported objects.
+func RecordedGroupID(u *unstructured.Unstructured) string {
+	return RecordedID(u, "groupId", "mongodb.com/external-group-id")
+}
+
+// OrgID returns the Atlas organization ID of u, either of the Organization referenced in spec.<version>.orgRef
+// or spec.<version>.parameters.orgId. The ID is recorded in status so it stays available for
+// deletion after the referenced Organization is gone.
+func OrgID(ctx context.Context, c client.Reader, u *unstructured.Unstructured, version string) (string, error) {
+	org, err := Get(ctx, c, u, "Organization", "spec", version, "orgRef")
+	if err != nil {
+		return "", err
+	}
+
+	var orgID string
+	if org != nil {
+		orgID, _, _ = unstructured.NestedString(org.Object, "status", "v20231115", "id")
+		if orgID == "" {
+			return "", fmt.Errorf("referenced Organization %s is not created yet", org.GetName())
+		}
+	} else {
+		orgID, _, _ = unstructured.NestedString(u.Object, "spec", version, "parameters", "orgId")
+	}
+
+	if orgID == "" {
+		return "", fmt.Errorf("neither spec.%s.orgRef nor spec.%s.parameters.orgId is set", version, version)
+	}
+
+	SetID(u, "orgId", orgID)
+	return orgID, nil
+}
+
+// RecordedOrgID returns the Atlas organization ID recorded by OrgID,
+// falling back to the mongodb.com/external-org-id annotation of imported objects.
+func RecordedOrgID(u *unstructured.Unstructured) string {
+	return RecordedID(u, "orgId", "mongodb.com/external-org-id")
+}
+
+// ID returns an Atlas identifier recorded in status.ids.
+func ID(u *unstructured.Unstructured, name string) string {
+	v, _, _ := unstructured.NestedString(u.Object, "status", "ids", name)
+	return v
+}
+
+// SetID records an Atlas identifier of a parent resource in status.ids.
+func SetID(u *unstructured.Unstructured, name, value string) {
+	if err := unstructured.SetNestedField(u.Object, value, "status", "ids", name); err != nil {
+		panic(err)
+	}
+}
+
+// Cluster returns the Atlas project ID and name of the Cluster referenced in spec.<version>.clusterRef.
+// Without a reference, spec.<version>.parameters.clusterName in the project resolved by GroupID is used.
+// Both are recorded in status so they stay available for deletion after the referenced Cluster is gone.
+func Cluster(ctx context.Context, c client.Reader, u *unstructured.Unstructured, version string) (string, string, error) {
+	cluster, err := Get(ctx, c, u, "Cluster", "spec", version, "clusterRef")
+	if err != nil {
+		return "", "", err
+	}
+
+	var groupID, clusterName string
+	if cluster != nil {
+		if !IsReady(cluster) {
+			return "", "", fmt.Errorf("referenced Cluster %s is not ready yet", cluster.GetName())
+		}
+		groupID, _, _ = unstructured.NestedString(cluster.Object, "status", "v20231115", "groupId")
+		clusterName, _, _ = unstructured.NestedString(cluster.Object, "status", "v20231115", "name")
+		SetID(u, "groupId", groupID)
+	} else {
+		if groupID, err = GroupID(ctx, c, u, version); err != nil {
+			return "", "", err
+		}
+		clusterName, _, _ = unstructured.NestedString(u.Object, "spec", version, "parameters", "clusterName")
+	}
+
+	if clusterName == "" {
+		return "", "", fmt.Errorf("neither spec.%s.clusterRef nor spec.%s.parameters.clusterName is set", version, version)
+	}
+
+	SetID(u, "clusterName", clusterName)
+	return groupID, clusterName, nil
+}
+
+// RecordedClusterName returns the cluster name recorded by Cluster,
+// falling back to the mongodb.com/external-cluster-name annotation of imported objects.
+func RecordedClusterName(u *unstructured.Unstructured) string {
+	return RecordedID(u, "clusterName", "mongodb.com/external-cluster-name")
+}
+
+// RecordedID returns an Atlas identifier recorded in status.ids,
+// falling back to the given annotation of imported objects.
+// It backs RecordedGroupID, RecordedOrgID and RecordedClusterName, and serves kinds recording identifiers
+// of their own, i.e. the team ID of a TeamAssignment.
+func RecordedID(u *unstructured.Unstructured, name, annotation string) string {
+	if v := ID(u, name); v != "" {
+		return v
+	}
+	return u.GetAnnotations()[annotation]
+}
@@ -0,0 +1,110 @@
+package secret
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	internalunstructured "github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/controller/unstructured"
+	"github.com/mongodb/mongodb-atlas-kubernetes/v3/internal/json"
+)
+
+// Ref references a key of a Secret in the namespace of the referencing object.
+type Ref struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// GetRef returns the Secret reference at the given fields or nil if it is not set.
+func GetRef(u *unstructured.Unstructured, fields ...string) *Ref {
+	v, found, _ := unstructured.NestedMap(u.Object, fields...)
+	if !found {
+		return nil
+	}
+	ref := json.Convert[Ref](v)
+	if ref.Name == "" {
+		return nil
+	}
+	return ref
+}
+
+// Value returns the value of the referenced Secret key. If the reference has no key, defaultKey is used.
+func Value(ctx context.Context, c client.Reader, namespace string, ref *Ref, defaultKey string) (string, error) {
+	key := ref.Key
+	if key == "" {
+		key = defaultKey
+	}
+
+	data, err := Data(ctx, c, namespace, ref)
+	if err != nil {
+		return "", err
+	}
+
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", ref.Name, key)
+	}
+	return string(v), nil
+}
+
+// Data returns all keys of the referenced Secret.
+func Data(ctx context.Context, c client.Reader, namespace string, ref *Ref) (map[string][]byte, error) {
+	s := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, s); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+	}
+	return s.Data, nil
+}
+
+// Hash returns a digest of the given secret values suitable to be stored in status.
+func Hash(values ...string) string {
+	h := sha256.New()
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AppliedHash returns the hash of the secret values last applied to Atlas.
+func AppliedHash(u *unstructured.Unstructured) string {
+	v, _, _ := unstructured.NestedString(u.Object, "status", "appliedSecretHash")
+	return v
+}
+
+// SetAppliedHash records the hash of the secret values applied to Atlas.
+func SetAppliedHash(u *unstructured.Unstructured, hash string) {
+	if err := unstructured.SetNestedField(u.Object, hash, "status", "appliedSecretHash"); err != nil {
+		panic(err)
+	}
+}
+
+// Watch enqueues all objects in the namespace of a changed Secret referencing it by name at the given fields.
+func Watch(fields ...string) internalunstructured.Watch {
+	return internalunstructured.Watch{
+		Object: &corev1.Secret{},
+		Map: func(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, o client.Object) []reconcile.Request {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := c.List(ctx, list, client.InNamespace(o.GetNamespace())); err != nil {
+				return nil
+			}
+
+			var requests []reconcile.Request
+			for _, item := range list.Items {
+				if name, _, _ := unstructured.NestedString(item.Object, fields...); name == o.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+				}
+			}
+			return requests
+		},
+	}
+}